@@ -2,8 +2,11 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"strings"
+	"time"
 
+	"github.com/OCARC/mikrotik-openconfig/openconfig"
 	"github.com/go-routeros/routeros"
 )
 
@@ -16,6 +19,39 @@ func NewMikroTikClient(addr, user, pass string) (*routeros.Client, error) {
 	return routeros.Dial(addr, user, pass)
 }
 
+// ManagementServiceForAddr returns the /ip/service an API address connects to, for
+// DeviceState.ManagementService: "api" on 8728, "api-ssl" on 8729 and "" for other ports
+func ManagementServiceForAddr(addr string) string {
+	if !strings.Contains(addr, ":") {
+		return "api"
+	}
+	switch addr[strings.LastIndex(addr, ":")+1:] {
+	case "8728":
+		return "api"
+	case "8729":
+		return "api-ssl"
+	}
+	return ""
+}
+
+// ManagementSourceForAddr returns the local address used to reach an API address, for
+// DeviceState.ManagementSource, or "" when no route is known. No packets are sent; a device
+// behind NAT sees a different address and should have ManagementSource set by hand.
+func ManagementSourceForAddr(addr string) string {
+	if !strings.Contains(addr, ":") || strings.HasSuffix(addr, "]") {
+		addr = net.JoinHostPort(strings.Trim(addr, "[]"), "8728")
+	}
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return ""
+	}
+	defer conn.Close()
+	if local, ok := conn.LocalAddr().(*net.UDPAddr); ok {
+		return local.IP.String()
+	}
+	return ""
+}
+
 // CommandRunner abstracts the RunArgs method for mocking/testing
 type CommandRunner interface {
 	RunArgs([]string) (*routeros.Reply, error)
@@ -32,19 +68,129 @@ func SendCommands(client CommandRunner, cmds []string) error {
 	return nil
 }
 
+// LoadDeviceState reads the RouterOS version and prints every menu the translator
+// reconciles against, collecting the replies for TranslateNetconfToMikrotikWithState
+func LoadDeviceState(client CommandRunner) (*openconfig.DeviceState, error) {
+	state := &openconfig.DeviceState{Tables: map[string][]map[string]string{}}
+	reply, err := client.RunArgs([]string{"/system/resource/print"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read /system/resource: %w", err)
+	}
+	if len(reply.Re) > 0 {
+		state.Version = reply.Re[0].Map["version"]
+	}
+	for _, menu := range state.ReconciledTables() {
+		reply, err := client.RunArgs([]string{menu + "/print"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", menu, err)
+		}
+		for _, re := range reply.Re {
+			state.Tables[menu] = append(state.Tables[menu], re.Map)
+		}
+	}
+	return state, nil
+}
+
+// RunGet translates a NETCONF <get>, runs its print commands on the device and returns the
+// <data> of the reply built from what the device printed
+func RunGet(client CommandRunner, xmlInput string) ([]byte, error) {
+	cmds, err := TranslateNetconfToMikrotik(xmlInput)
+	if err != nil {
+		return nil, err
+	}
+	replies := openconfig.Replies{}
+	for _, cmd := range cmds {
+		if _, done := replies[cmd]; done || strings.HasPrefix(cmd, "UNSUPPORTED") {
+			continue
+		}
+		reply, err := client.RunArgs(apiArgs(cmd))
+		if err != nil {
+			return nil, fmt.Errorf("failed to run command %q: %w", cmd, err)
+		}
+		rows := []map[string]string{}
+		for _, re := range reply.Re {
+			rows = append(rows, re.Map)
+		}
+		replies[cmd] = rows
+	}
+	return BuildGetReply(xmlInput, replies)
+}
+
+// apiArgs is parseCommand for the RouterOS API sentence format: properties are sent as
+// =name=value words, and proplist as =.proplist=
+func apiArgs(cmd string) []string {
+	args := parseCommand(cmd)
+	for i := 1; i < len(args); i++ {
+		switch {
+		case strings.HasPrefix(args[i], "="), strings.HasPrefix(args[i], "?"):
+		case strings.HasPrefix(args[i], "proplist="):
+			args[i] = "=." + args[i]
+		default:
+			args[i] = "=" + args[i]
+		}
+	}
+	return args
+}
+
+// SampleCPULoad prints /system/resource/cpu the given number of times, spread evenly over window,
+// for openconfig.SystemCPUsFromMikrotik to derive avg/min/max load over the window
+func SampleCPULoad(client CommandRunner, samples int, window time.Duration) ([][]map[string]string, error) {
+	var result [][]map[string]string
+	for i := 0; i < samples; i++ {
+		if i > 0 {
+			time.Sleep(window / time.Duration(samples-1))
+		}
+		reply, err := client.RunArgs([]string{"/system/resource/cpu/print"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read /system/resource/cpu: %w", err)
+		}
+		var rows []map[string]string
+		for _, re := range reply.Re {
+			rows = append(rows, re.Map)
+		}
+		result = append(result, rows)
+	}
+	return result, nil
+}
+
 // parseCommand splits a command string into args for RunArgs
 func parseCommand(cmd string) []string {
-	// Simple split by space, but handle quoted args
+	// Simple split by space, but handle quoted args, backslash escapes
+	// inside quotes (\n, \r and \t stand for control characters, so
+	// multi-line values stay on one line) and [find ...] selectors
 	var args []string
 	var current string
 	inQuotes := false
+	brackets := 0
 	for i := 0; i < len(cmd); i++ {
 		c := cmd[i]
+		if c == '\\' && inQuotes && i+1 < len(cmd) {
+			i++
+			switch cmd[i] {
+			case 'n':
+				current += "\n"
+			case 'r':
+				current += "\r"
+			case 't':
+				current += "\t"
+			default:
+				current += string(cmd[i])
+			}
+			continue
+		}
 		if c == '"' {
 			inQuotes = !inQuotes
 			continue
 		}
-		if c == ' ' && !inQuotes {
+		if !inQuotes {
+			switch c {
+			case '[':
+				brackets++
+			case ']':
+				brackets--
+			}
+		}
+		if c == ' ' && !inQuotes && brackets == 0 {
 			if current != "" {
 				args = append(args, current)
 				current = ""
@@ -2,16 +2,19 @@ package main
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/go-routeros/routeros"
+	"github.com/go-routeros/routeros/proto"
 )
 
 // mockClient implements the minimal routeros.Client interface for testing
 // Only RunArgs is used in SendCommands
 type mockClient struct {
-	calls [][]string
-	fail  bool
+	calls   [][]string
+	fail    bool
+	records map[string][]map[string]string // !re rows returned per command word
 }
 
 func (m *mockClient) RunArgs(args []string) (*routeros.Reply, error) {
@@ -19,7 +22,11 @@ func (m *mockClient) RunArgs(args []string) (*routeros.Reply, error) {
 	if m.fail {
 		return nil, errors.New("mock failure")
 	}
-	return &routeros.Reply{}, nil
+	reply := &routeros.Reply{}
+	for _, rec := range m.records[args[0]] {
+		reply.Re = append(reply.Re, &proto.Sentence{Word: "!re", Map: rec})
+	}
+	return reply, nil
 }
 
 func TestSendCommands_Success(t *testing.T) {
@@ -56,3 +63,148 @@ func TestParseCommand(t *testing.T) {
 		}
 	}
 }
+
+func TestParseCommand_FindSelectorAndEscapes(t *testing.T) {
+	cmd := `/interface/bridge/port/set [find interface=ether2] comment="say \"hi\""`
+	args := parseCommand(cmd)
+	expected := []string{"/interface/bridge/port/set", "[find interface=ether2]", `comment=say "hi"`}
+	if len(args) != len(expected) {
+		t.Fatalf("expected %d args, got %d: %q", len(expected), len(args), args)
+	}
+	for i := range args {
+		if args[i] != expected[i] {
+			t.Errorf("arg %d: expected %q, got %q", i, expected[i], args[i])
+		}
+	}
+}
+
+func TestParseCommand_MultiLineValue(t *testing.T) {
+	cmd := `/system/note/set note="line 1\nline\t2\\n" show-at-login=yes`
+	args := parseCommand(cmd)
+	expected := []string{"/system/note/set", "note=line 1\nline\t2\\n", "show-at-login=yes"}
+	if len(args) != len(expected) {
+		t.Fatalf("expected %d args, got %d: %q", len(expected), len(args), args)
+	}
+	for i := range args {
+		if args[i] != expected[i] {
+			t.Errorf("arg %d: expected %q, got %q", i, expected[i], args[i])
+		}
+	}
+}
+
+func TestLoadDeviceState(t *testing.T) {
+	mc := &mockClient{records: map[string][]map[string]string{
+		"/system/resource/print": {{"version": "7.12.1 (stable)"}},
+		"/ip/route/print":        {{".id": "*1", "dst-address": "0.0.0.0/0", "gateway": "192.0.2.1"}},
+	}}
+	state, err := LoadDeviceState(mc)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(mc.calls) != len(state.ReconciledTables())+1 {
+		t.Errorf("expected %d calls, got %d", len(state.ReconciledTables())+1, len(mc.calls))
+	}
+	if state.Version != "7.12.1 (stable)" {
+		t.Errorf("expected version 7.12.1 (stable), got %q", state.Version)
+	}
+	if rows := state.Tables["/ip/route"]; len(rows) != 1 || rows[0]["gateway"] != "192.0.2.1" {
+		t.Errorf("unexpected /ip/route rows: %v", rows)
+	}
+}
+
+func TestLoadDeviceState_Failure(t *testing.T) {
+	if _, err := LoadDeviceState(&mockClient{fail: true}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestRunGet(t *testing.T) {
+	mc := &mockClient{records: map[string][]map[string]string{
+		"/system/identity/print":     {{"name": "router1"}},
+		"/system/ntp/client/print":   {{"enabled": "true"}},
+		"/system/ntp/client/monitor": {{"synced-server": "192.0.2.123", "synced-stratum": "2"}},
+		"/ip/route/print": {
+			{".id": "*1", "dst-address": "10.0.0.0/8", "gateway": "192.0.2.1", "distance": "10", "comment": "0"},
+			{".id": "*2", "dst-address": "0.0.0.0/0", "gateway": "192.0.2.254", "dynamic": "true"},
+		},
+	}}
+	get := `<rpc><get><filter><system><hostname/><ntp/></system>` +
+		`<network-instances><network-instance><name>DEFAULT</name><protocols><protocol><identifier>STATIC</identifier><static-routes/></protocol></protocols></network-instance></network-instances>` +
+		`</filter></get></rpc>`
+	data, err := RunGet(mc, get)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := `<data><system><hostname>router1</hostname><ntp><servers><server><address>192.0.2.123</address>` +
+		`<state><address>192.0.2.123</address><stratum>2</stratum></state></server></servers><enabled>true</enabled></ntp></system>` +
+		`<network-instances><network-instance><name>DEFAULT</name><protocols><protocol><identifier>STATIC</identifier>` +
+		`<static-routes><static><prefix>10.0.0.0/8</prefix><config><prefix>10.0.0.0/8</prefix></config><state><prefix>10.0.0.0/8</prefix></state>` +
+		`<next-hops><next-hop><index>0</index><config><index>0</index><next-hop>192.0.2.1</next-hop><metric>10</metric></config>` +
+		`<state><index>0</index><next-hop>192.0.2.1</next-hop><metric>10</metric></state></next-hop></next-hops></static></static-routes>` +
+		`</protocol></protocols></network-instance></network-instances></data>`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+	if len(mc.calls) != 5 {
+		t.Errorf("expected 5 calls, got %d: %v", len(mc.calls), mc.calls)
+	}
+	for _, call := range mc.calls {
+		if call[0] == "/system/ntp/client/monitor" && (len(call) != 2 || call[1] != "=once=") {
+			t.Errorf("expected the monitor to be sent =once=, got %v", call)
+		}
+	}
+}
+
+func TestAPIArgs(t *testing.T) {
+	args := apiArgs(`/user/print proplist=name,group`)
+	expected := []string{"/user/print", "=.proplist=name,group"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("expected %v, got %v", expected, args)
+	}
+	args = apiArgs(`/ip/firewall/filter/add chain=acl-mgmt comment="acl:mgmt:10" =action=accept`)
+	expected = []string{"/ip/firewall/filter/add", "=chain=acl-mgmt", "=comment=acl:mgmt:10", "=action=accept"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("expected %v, got %v", expected, args)
+	}
+}
+
+func TestSampleCPULoad(t *testing.T) {
+	mc := &mockClient{records: map[string][]map[string]string{
+		"/system/resource/cpu/print": {{"cpu": "cpu0", "load": "12"}, {"cpu": "cpu1", "load": "3"}},
+	}}
+	samples, err := SampleCPULoad(mc, 3, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(samples) != 3 || len(samples[2]) != 2 || samples[2][0]["load"] != "12" {
+		t.Errorf("unexpected samples: %v", samples)
+	}
+}
+
+func TestManagementServiceForAddr(t *testing.T) {
+	tests := map[string]string{
+		"192.168.88.1":      "api",
+		"192.168.88.1:8728": "api",
+		"192.168.88.1:8729": "api-ssl",
+		"[fe80::1]:8729":    "api-ssl",
+		"192.168.88.1:9000": "",
+	}
+	for addr, expected := range tests {
+		if got := ManagementServiceForAddr(addr); got != expected {
+			t.Errorf("%s: expected %q, got %q", addr, expected, got)
+		}
+	}
+}
+
+func TestManagementSourceForAddr(t *testing.T) {
+	tests := map[string]string{
+		"127.0.0.1":              "127.0.0.1",
+		"127.0.0.1:8729":         "127.0.0.1",
+		"127.0.0.1:no-such-port": "",
+	}
+	for addr, expected := range tests {
+		if got := ManagementSourceForAddr(addr); got != expected {
+			t.Errorf("%s: expected %q, got %q", addr, expected, got)
+		}
+	}
+}
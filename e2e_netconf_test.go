@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/xml"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -45,6 +46,44 @@ func TestE2ENetconfFiles(t *testing.T) {
 	}
 }
 
+// TestNetconfFilesTranslate checks every E2E file translates without a device, so a broken
+// test file shows up before it is run against one
+func TestNetconfFilesTranslate(t *testing.T) {
+	files, err := filepath.Glob("netconf-tests/*.xml")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("No test files found: %v", err)
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		for _, rpcOp := range splitRPCOperations(string(data)) {
+			if _, err := TranslateNetconfToMikrotik(rpcOp); err != nil {
+				t.Errorf("%s: %v", filepath.Base(file), err)
+			}
+		}
+	}
+}
+
+// TestE2EVerifyHelpers checks the get filter keeps list keys and the reply is matched by leaf path
+func TestE2EVerifyHelpers(t *testing.T) {
+	config := `<system xc:operation="merge"><services><service><name>ftp</name>` +
+		`<config><name>ftp</name><enabled>false</enabled></config></service></services></system>`
+	want := `<system><services><service><name>ftp</name><config><name/><enabled/></config></service></services></system>`
+	if got := convertEditConfigToGetFilter(config); got != want {
+		t.Errorf("filter: got %s, want %s", got, want)
+	}
+	values := extractLeafValues(`<data><system><services><service><name>ftp</name><config><enabled>true</enabled></config></service>` +
+		`<service><name>ssh</name><config><enabled>false</enabled></config></service></services></system></data>`)
+	if got := values["system/services/service/config/enabled"]; len(got) != 2 || got[1] != "false" {
+		t.Errorf("leaf values: got %v", values)
+	}
+	if _, ok := values["system/services/service/enabled"]; ok {
+		t.Errorf("leaf matched outside its path: %v", values)
+	}
+}
+
 func runNetconfTest(t *testing.T, c *routeros.Client, file string) {
 	data, err := ioutil.ReadFile(file)
 	if err != nil {
@@ -151,8 +190,17 @@ func handleConditionalOperation(t *testing.T, c *routeros.Client, rpcOps []strin
 
 // Execute a single RPC operation
 func executeRPCOperation(t *testing.T, c *routeros.Client, rpcOp string) {
+	// Read the device state first so keyed lists are reconciled and re-runs do not add duplicates
+	state, err := LoadDeviceState(c)
+	if err != nil {
+		t.Errorf("failed to load device state: %v", err)
+		return
+	}
+	state.ManagementService = ManagementServiceForAddr(os.Getenv("MIKROTIK_ADDR"))
+	state.ManagementSource = ManagementSourceForAddr(os.Getenv("MIKROTIK_ADDR"))
+
 	// Generate and execute MikroTik commands using existing translator
-	cmds, err := TranslateNetconfToMikrotik(rpcOp)
+	cmds, err := TranslateNetconfToMikrotikWithState(rpcOp, state)
 	if err != nil {
 		t.Logf("Translation failed for RPC operation: %v", err)
 		return
@@ -182,16 +230,14 @@ func executeRPCOperation(t *testing.T, c *routeros.Client, rpcOp string) {
 		if len(validCmds) > 1 && !strings.HasPrefix(validCmds[1], "/") {
 			// This looks like a command with arguments - combine them
 			combinedCmd := strings.Join(validCmds, " ")
-			cmdArgs := parseE2ECommand(combinedCmd)
-			_, err := c.RunArgs(cmdArgs)
+			_, err := c.RunArgs(apiArgs(combinedCmd))
 			if err != nil {
 				t.Errorf("MikroTik command failed: %s - %v", combinedCmd, err)
 			}
 		} else {
 			// Execute each command separately
 			for _, cmd := range validCmds {
-				cmdArgs := parseE2ECommand(cmd)
-				_, err := c.RunArgs(cmdArgs)
+				_, err := c.RunArgs(apiArgs(cmd))
 				if err != nil {
 					t.Errorf("MikroTik command failed: %s - %v", cmd, err)
 				}
@@ -200,33 +246,10 @@ func executeRPCOperation(t *testing.T, c *routeros.Client, rpcOp string) {
 	}
 }
 
-// Parse command string into RouterOS API arguments
-func parseE2ECommand(command string) []string {
-	fields := strings.Fields(command)
-	if len(fields) == 0 {
-		return fields
-	}
-
-	// First field is the command path
-	result := []string{fields[0]}
-
-	// Remaining fields are parameters - need to add = prefix for RouterOS API
-	for i := 1; i < len(fields); i++ {
-		param := fields[i]
-		// RouterOS API expects =parameter=value format
-		if !strings.HasPrefix(param, "=") {
-			param = "=" + param
-		}
-		result = append(result, param)
-	}
-
-	return result
-}
-
 func verifyE2EChangesFromXML(t *testing.T, c *routeros.Client, xmlData string) {
 	// Extract the config section from edit-config
 	configStart := strings.Index(xmlData, "<config>")
-	configEnd := strings.Index(xmlData, "</config>")
+	configEnd := strings.LastIndex(xmlData, "</config>")
 	if configStart == -1 || configEnd == -1 {
 		return
 	}
@@ -242,108 +265,216 @@ func verifyE2EChangesFromXML(t *testing.T, c *routeros.Client, xmlData string) {
 	// Create full GET RPC
 	getXML := `<rpc><get><filter>` + getFilterXML + `</filter></get></rpc>`
 
-	// Execute the GET command via translator
-	cmds, err := TranslateNetconfToMikrotik(getXML)
-	if err != nil || len(cmds) == 0 {
-		return
-	}
-
-	// Execute the GET
-	cmdArgs := parseE2ECommand(cmds[0])
-	re, err := c.RunArgs(cmdArgs)
-	if err != nil || re == nil || len(re.Re) == 0 {
+	// Execute the GET and map the device's replies back into the OpenConfig tree
+	data, err := RunGet(c, getXML)
+	if err != nil {
+		t.Errorf("GET failed: %v", err)
 		return
 	}
 
-	// Extract expected values from original config and verify
-	verifyValues(t, configXML, re.Re[0].Map)
+	// Extract expected values from original config and verify them against the reply
+	verifyValues(t, configXML, extractLeafValues(string(data)))
 }
 
-// Convert edit-config XML to get filter by removing values and operation attributes
+// Convert edit-config XML to get filter by removing values and operation attributes. Leaves
+// outside a config container are list keys (or bare leaves such as system/hostname) and keep
+// their values, so the filter selects the list entries that were edited.
 func convertEditConfigToGetFilter(configXML string) string {
 	// Remove xc:operation attributes
 	opRegex := regexp.MustCompile(`\s+xc:operation="[^"]*"`)
 	cleanXML := opRegex.ReplaceAllString(configXML, "")
 
-	// Convert leaf nodes with values to empty leaf nodes for GET
-	// e.g., <hostname>test</hostname> -> <hostname/>
-	leafRegex := regexp.MustCompile(`<([^/>]+)>[^<]+</([^>]+)>`)
-	getFilterXML := leafRegex.ReplaceAllStringFunc(cleanXML, func(match string) string {
-		// Extract the tag name from the opening tag
-		tagStart := strings.Index(match, "<") + 1
-		tagEnd := strings.Index(match, ">")
-		if tagEnd > tagStart {
-			tagName := match[tagStart:tagEnd]
-			return "<" + tagName + "/>"
+	// Convert config leaf nodes with values to empty leaf nodes for GET
+	// e.g., <config><enabled>true</enabled></config> -> <config><enabled/></config>
+	leafRegex := regexp.MustCompile(`<([^/>\s]+)[^>]*>[^<]+</[^>]+>`)
+	var b strings.Builder
+	last := 0
+	for _, m := range leafRegex.FindAllStringSubmatchIndex(cleanXML, -1) {
+		b.WriteString(cleanXML[last:m[0]])
+		if parentElement(cleanXML[:m[0]]) == "config" {
+			b.WriteString("<" + cleanXML[m[2]:m[3]] + "/>")
+		} else {
+			b.WriteString(cleanXML[m[0]:m[1]])
 		}
-		return match
-	})
+		last = m[1]
+	}
+	b.WriteString(cleanXML[last:])
+	return b.String()
+}
 
-	return getFilterXML
+// parentElement returns the name of the innermost element still open at the end of an XML prefix
+func parentElement(prefix string) string {
+	tagRegex := regexp.MustCompile(`<(/?)([^\s>/!?]+)[^>]*?(/?)>`)
+	var open []string
+	for _, m := range tagRegex.FindAllStringSubmatch(prefix, -1) {
+		switch {
+		case m[3] == "/":
+		case m[1] == "/":
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+			}
+		default:
+			open = append(open, m[2])
+		}
+	}
+	if len(open) == 0 {
+		return ""
+	}
+	return open[len(open)-1]
 }
 
-// Verify that the actual values match the expected values from the config
-func verifyValues(t *testing.T, configXML string, actualValues map[string]string) {
-	// Extract expected values from the config XML
-	expectedValues := extractExpectedValues(configXML)
+// e2eWriteOnlyLeaves lists, per test file, the edited leaves a get cannot read back: secrets the
+// device never prints and config of set-only modules (see "Set Only" in SUPPORTED_MODULES.md).
+// Every other edited leaf must come back from the device with the value that was written.
+var e2eWriteOnlyLeaves = map[string][]string{
+	// RouterOS 7 keeps the NTP server list under /system/ntp/client/servers, which get does not print
+	"add_ntp_servers.xml": {
+		"system/ntp/servers/server/address",
+		"system/ntp/servers/server/config/address",
+		"system/ntp/servers/server/config/iburst",
+	},
+	"set_ntp_server.xml": {"system/ntp/ntp-server/config/enabled"},
+	"set_acl.xml": {
+		"acl/acl-sets/acl-set/config/name",
+		"acl/acl-sets/acl-set/config/type",
+		"acl/acl-sets/acl-set/acl-entries/acl-entry/config/sequence-id",
+		"acl/acl-sets/acl-set/acl-entries/acl-entry/ipv4/config/destination-address",
+		"acl/acl-sets/acl-set/acl-entries/acl-entry/actions/config/forwarding-action",
+	},
+	"set_aggregation.xml": {
+		"interfaces/interface/name",
+		"interfaces/interface/aggregation/config/lag-type",
+		"interfaces/interface/aggregation/config/min-links",
+	},
+	"set_bgp_neighbor.xml": {
+		"network-instances/network-instance/protocols/protocol/bgp/neighbors/neighbor/neighbor-address",
+		"network-instances/network-instance/protocols/protocol/bgp/neighbors/neighbor/config/neighbor-address",
+		"network-instances/network-instance/protocols/protocol/bgp/neighbors/neighbor/config/peer-as",
+		"network-instances/network-instance/protocols/protocol/bgp/neighbors/neighbor/config/description",
+		"network-instances/network-instance/protocols/protocol/bgp/neighbors/neighbor/config/enabled",
+	},
+	"set_bridge_vlan.xml": {
+		"vlans/vlan/vlan-id",
+		"vlans/vlan/config/vlan-id",
+		"vlans/vlan/config/name",
+	},
+	"set_ethernet_autoneg.xml": {
+		"interfaces/interface/name",
+		"interfaces/interface/ethernet/config/auto-negotiate",
+	},
+	"set_lldp.xml": {"lldp/config/enabled"},
+	"set_ospf_router_id.xml": {
+		"network-instances/network-instance/protocols/protocol/ospfv2/global/config/router-id",
+	},
+	"set_qos_classifier.xml": {
+		"qos/classifiers/classifier/name",
+		"qos/classifiers/classifier/terms/term/id",
+		"qos/classifiers/classifier/terms/term/conditions/ipv4/config/dscp",
+		"qos/classifiers/classifier/terms/term/actions/config/target-group",
+	},
+	// RouterOS never prints the RADIUS secret; the rest of the server group is set-only
+	"set_radius_server_group.xml": {
+		"system/aaa/server-groups/server-group/name",
+		"system/aaa/server-groups/server-group/config/name",
+		"system/aaa/server-groups/server-group/config/type",
+		"system/aaa/server-groups/server-group/servers/server/address",
+		"system/aaa/server-groups/server-group/servers/server/radius/config/auth-port",
+		"system/aaa/server-groups/server-group/servers/server/radius/config/secret-key",
+	},
+	"set_routing_policy.xml": {
+		"routing-policy/policy-definitions/policy-definition/name",
+		"routing-policy/policy-definitions/policy-definition/config/name",
+		"routing-policy/policy-definitions/policy-definition/statements/statement/name",
+		"routing-policy/policy-definitions/policy-definition/statements/statement/actions/config/policy-result",
+	},
+	"set_vrf.xml": {
+		"network-instances/network-instance/config/name",
+		"network-instances/network-instance/config/type",
+	},
+}
 
-	for field, expected := range expectedValues {
-		// Map NETCONF field names to RouterOS field names
-		rosField := mapNetconfToRouterOSField(field)
+// Verify that the actual values match the expected values from the config. Leaves are compared
+// by their full path, and a leaf missing from the reply fails unless the test lists it as write-only.
+func verifyValues(t *testing.T, configXML string, actualValues map[string][]string) {
+	writeOnly := map[string]bool{}
+	for _, leaf := range e2eWriteOnlyLeaves[filepath.Base(t.Name())] {
+		writeOnly[leaf] = true
+	}
 
-		if actual, ok := actualValues[rosField]; ok {
-			// Handle boolean conversions
-			actualValue := normalizeValue(actual)
-			expectedValue := normalizeValue(expected)
+	// Extract expected values from the config XML
+	expectedValues := extractLeafValues(configXML)
 
-			if actualValue != expectedValue {
-				t.Errorf("%s verification failed: expected %s, got %s", field, expectedValue, actualValue)
-			} else if testing.Verbose() {
-				fmt.Println("\t\t" + field + " verification passed: " + expectedValue)
+	for field, expected := range expectedValues {
+		actual, ok := actualValues[field]
+		if !ok {
+			if writeOnly[field] {
+				if testing.Verbose() {
+					t.Logf("Field %s is write-only, not verified", field)
+				}
+				continue
 			}
-		} else {
-			t.Errorf("Field %s not found in RouterOS response (mapped to %s)", field, rosField)
+			t.Errorf("Field %s not found in RouterOS response", field)
 			if testing.Verbose() {
 				t.Logf("Available fields: %v", actualValues)
 			}
+			continue
+		}
+		for _, want := range expected {
+			// Handle boolean conversions
+			expectedValue := normalizeValue(want)
+			matched := false
+			for _, v := range actual {
+				if normalizeValue(v) == expectedValue {
+					matched = true
+				}
+			}
+			if !matched {
+				t.Errorf("%s verification failed: expected %s, got %v", field, expectedValue, actual)
+			} else if testing.Verbose() {
+				fmt.Println("\t\t" + field + " verification passed: " + expectedValue)
+			}
 		}
 	}
 }
 
-// Extract expected values from config XML
-func extractExpectedValues(configXML string) map[string]string {
-	values := make(map[string]string)
-
-	// Use regex to find leaf elements with values
-	leafRegex := regexp.MustCompile(`<([^/>]+)>([^<]+)</[^>]+>`)
-	matches := leafRegex.FindAllStringSubmatch(configXML, -1)
-
-	for _, match := range matches {
-		if len(match) >= 3 {
-			fieldName := match[1]
-			fieldValue := match[2]
-			values[fieldName] = fieldValue
+// Extract every leaf value of an XML tree keyed by its path of element names, e.g.
+// system/config/hostname. List entries share a path, so a path can hold several values.
+// The <data> wrapper of a get reply is dropped so reply paths line up with config paths.
+func extractLeafValues(xmlData string) map[string][]string {
+	values := make(map[string][]string)
+	dec := xml.NewDecoder(strings.NewReader(xmlData))
+	var path []string
+	var text string
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if len(path) == 0 && tok.Name.Local == "data" {
+				continue
+			}
+			path = append(path, tok.Name.Local)
+			text = ""
+		case xml.CharData:
+			text += string(tok)
+		case xml.EndElement:
+			if len(path) == 0 {
+				continue
+			}
+			if value := strings.TrimSpace(text); value != "" {
+				key := strings.Join(path, "/")
+				values[key] = append(values[key], value)
+			}
+			path = path[:len(path)-1]
+			text = ""
 		}
 	}
-
 	return values
 }
 
-// Map NETCONF field names to RouterOS field names
-func mapNetconfToRouterOSField(netconfField string) string {
-	fieldMap := map[string]string{
-		"hostname":      "name",
-		"timezone-name": "time-zone-name",
-		"enabled":       "enabled",
-	}
-
-	if rosField, ok := fieldMap[netconfField]; ok {
-		return rosField
-	}
-	return netconfField // fallback to original name
-}
-
-// Normalize values for comparison (handle boolean conversions)
+// Normalize values for comparison (handle boolean conversions and identity prefixes)
 func normalizeValue(value string) string {
 	switch strings.ToLower(value) {
 	case "true", "yes", "1":
@@ -351,6 +482,9 @@ func normalizeValue(value string) string {
 	case "false", "no", "0":
 		return "false"
 	default:
-		return value
+		return identityRegex.ReplaceAllString(value, "$1")
 	}
 }
+
+// identityRegex matches a prefixed identity such as oc-types:IPV4, which a reply may carry bare
+var identityRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*:([A-Z][A-Z0-9_]*)$`)
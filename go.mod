@@ -2,4 +2,4 @@ module github.com/OCARC/mikrotik-openconfig
 
 go 1.21
 
-require github.com/go-routeros/routeros v0.0.0-20210123142807-2a44d57c6730 // indirect
+require github.com/go-routeros/routeros v0.0.0-20210123142807-2a44d57c6730
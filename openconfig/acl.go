@@ -0,0 +1,158 @@
+package openconfig
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ACL represents the openconfig-acl top-level container
+type ACL struct {
+	ACLSets    *ACLSets       `xml:"acl-sets"`
+	Interfaces *ACLInterfaces `xml:"interfaces"`
+}
+
+type ACLSets struct {
+	ACLSet []ACLSet `xml:"acl-set"`
+}
+
+type ACLSet struct {
+	Name       *string       `xml:"name"`
+	Type       *string       `xml:"type"` // ACL_IPV4 or ACL_IPV6
+	Config     *ACLSetConfig `xml:"config"`
+	ACLEntries *ACLEntries   `xml:"acl-entries"`
+}
+
+type ACLSetConfig struct {
+	Name *string `xml:"name"`
+	Type *string `xml:"type"`
+}
+
+type ACLEntries struct {
+	ACLEntry []ACLEntry `xml:"acl-entry"`
+}
+
+type ACLEntry struct {
+	Operation  *string         `xml:"operation,attr"`
+	SequenceID *uint32         `xml:"sequence-id"`
+	Config     *ACLEntryConfig `xml:"config"`
+	State      *ACLEntryState  `xml:"state"`
+	IPv4       *ACLIPMatch     `xml:"ipv4"`
+	IPv6       *ACLIPMatch     `xml:"ipv6"`
+	Transport  *ACLTransport   `xml:"transport"`
+	Actions    *ACLActions     `xml:"actions"`
+}
+
+type ACLEntryConfig struct {
+	SequenceID *uint32 `xml:"sequence-id"`
+}
+
+type ACLEntryState struct {
+	SequenceID     *uint32 `xml:"sequence-id"`
+	MatchedPackets *uint64 `xml:"matched-packets"`
+	MatchedOctets  *uint64 `xml:"matched-octets"`
+}
+
+type ACLIPMatch struct {
+	Config *ACLIPMatchConfig `xml:"config"`
+}
+
+type ACLIPMatchConfig struct {
+	SourceAddress      *string `xml:"source-address"`
+	DestinationAddress *string `xml:"destination-address"`
+	Protocol           *string `xml:"protocol"` // IP_TCP, IP_UDP, ... or a protocol number
+}
+
+type ACLTransport struct {
+	Config *ACLTransportConfig `xml:"config"`
+}
+
+type ACLTransportConfig struct {
+	SourcePort      *string `xml:"source-port"` // port, "1000..2000" range or ANY
+	DestinationPort *string `xml:"destination-port"`
+}
+
+type ACLActions struct {
+	Config *ACLActionsConfig `xml:"config"`
+}
+
+type ACLActionsConfig struct {
+	ForwardingAction *string `xml:"forwarding-action"` // ACCEPT, DROP or REJECT
+}
+
+type ACLInterfaces struct {
+	Interface []ACLInterface `xml:"interface"`
+}
+
+type ACLInterface struct {
+	ID             *string         `xml:"id"`
+	IngressACLSets *IngressACLSets `xml:"ingress-acl-sets"`
+}
+
+type IngressACLSets struct {
+	IngressACLSet []IngressACLSet `xml:"ingress-acl-set"`
+}
+
+type IngressACLSet struct {
+	SetName *string       `xml:"set-name"`
+	Type    *string       `xml:"type"`
+	Config  *ACLSetConfig `xml:"config"`
+}
+
+// key returns the acl-set name and type identity, falling back to config
+func (s *ACLSet) key() (string, string) {
+	return aclSetKey(s.Name, s.Type, s.Config)
+}
+
+// key returns the referenced acl-set name and type identity, falling back to config
+func (s *IngressACLSet) key() (string, string) {
+	return aclSetKey(s.SetName, s.Type, s.Config)
+}
+
+func aclSetKey(name, typ *string, cfg *ACLSetConfig) (string, string) {
+	n, t := "", "ACL_IPV4"
+	if name != nil {
+		n = *name
+	} else if cfg != nil && cfg.Name != nil {
+		n = *cfg.Name
+	}
+	if typ != nil {
+		t = identity(*typ)
+	} else if cfg != nil && cfg.Type != nil {
+		t = identity(*cfg.Type)
+	}
+	return n, t
+}
+
+// sequenceID returns the acl-entry key, falling back to config/sequence-id
+func (e *ACLEntry) sequenceID() (uint32, bool) {
+	if e.SequenceID != nil {
+		return *e.SequenceID, true
+	}
+	if e.Config != nil && e.Config.SequenceID != nil {
+		return *e.Config.SequenceID, true
+	}
+	return 0, false
+}
+
+// aclChain returns the dedicated /ip/firewall/filter chain holding an ACL set's entries
+func aclChain(set string) string {
+	return "acl-" + set
+}
+
+// aclEntryComment tags a firewall rule with the ACL entry it implements, so it can be reconciled
+func aclEntryComment(set string, seq uint32) string {
+	return "acl:" + set + ":" + strconv.FormatUint(uint64(seq), 10)
+}
+
+// aclSequenceFromComment returns the sequence-id a rule's comment tags it with for the given set
+func aclSequenceFromComment(set, comment string) (uint32, bool) {
+	rest, ok := strings.CutPrefix(comment, "acl:"+set+":")
+	if !ok {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(rest, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(seq), true
+}
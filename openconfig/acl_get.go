@@ -0,0 +1,86 @@
+package openconfig
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ACLGetToMikrotikCmds parses the filter XML and dispatches to the ACL handler for get operations.
+func ACLGetToMikrotikCmds(filterXML string) []string {
+	var f struct {
+		ACL *ACL `xml:"acl"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	return ACLToMikrotikCmds("get", f.ACL, nil)
+}
+
+// ACLEntriesStateFromMikrotik maps the rules of an acl-set's chain, as printed with stats,
+// onto acl-entry state: the packets and bytes counters become matched-packets and matched-octets.
+func ACLEntriesStateFromMikrotik(set string, rows []map[string]string) *ACLEntries {
+	entries := &ACLEntries{}
+	for _, row := range rows {
+		if row["chain"] != aclChain(set) {
+			continue
+		}
+		id, ok := aclSequenceFromComment(set, row["comment"])
+		if !ok {
+			continue
+		}
+		state := &ACLEntryState{SequenceID: &id}
+		if p, err := strconv.ParseUint(row["packets"], 10, 64); err == nil {
+			state.MatchedPackets = &p
+		}
+		if b, err := strconv.ParseUint(row["bytes"], 10, 64); err == nil {
+			state.MatchedOctets = &b
+		}
+		entries.ACLEntry = append(entries.ACLEntry, ACLEntry{SequenceID: &id, State: state})
+	}
+	return entries
+}
+
+// ACLGetFromMikrotik builds acl-entry state for the acl-sets of a get filter from the firewall filter
+// rows printed with stats. Without named sets, every set found in the rule comment tags is reported.
+func ACLGetFromMikrotik(filterXML string, replies Replies) *ACL {
+	var f struct {
+		ACL *ACL `xml:"acl"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	if f.ACL == nil {
+		return nil
+	}
+	rows := map[string][]map[string]string{
+		"ACL_IPV4": replies["/ip/firewall/filter/print stats="],
+		"ACL_IPV6": replies["/ipv6/firewall/filter/print stats="],
+	}
+	type setKey struct{ name, typ string }
+	var sets []setKey
+	if f.ACL.ACLSets != nil {
+		for _, set := range f.ACL.ACLSets.ACLSet {
+			if name, typ := set.key(); name != "" {
+				sets = append(sets, setKey{name, typ})
+			}
+		}
+	}
+	if len(sets) == 0 {
+		seen := map[setKey]bool{}
+		for _, typ := range []string{"ACL_IPV4", "ACL_IPV6"} {
+			for _, row := range rows[typ] {
+				tag, ok := strings.CutPrefix(row["comment"], "acl:")
+				if !ok {
+					continue
+				}
+				if i := strings.LastIndex(tag, ":"); i > 0 && !seen[setKey{tag[:i], typ}] {
+					seen[setKey{tag[:i], typ}] = true
+					sets = append(sets, setKey{tag[:i], typ})
+				}
+			}
+		}
+	}
+
+	out := &ACL{ACLSets: &ACLSets{}}
+	for _, k := range sets {
+		name, typ := k.name, k.typ
+		out.ACLSets.ACLSet = append(out.ACLSets.ACLSet, ACLSet{Name: &name, Type: &typ, ACLEntries: ACLEntriesStateFromMikrotik(name, rows[typ])})
+	}
+	return out
+}
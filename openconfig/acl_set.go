@@ -0,0 +1,308 @@
+package openconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// aclFilterMenus maps acl-set types onto the firewall filter menu holding their rules
+var aclFilterMenus = map[string]string{
+	"ACL_IPV4": "/ip/firewall/filter",
+	"ACL_IPV6": "/ipv6/firewall/filter",
+}
+
+var aclForwardingActions = map[string]string{
+	"ACCEPT": "accept",
+	"DROP":   "drop",
+	"REJECT": "reject",
+}
+
+// aclProtocols maps OpenConfig ip-protocol identities onto firewall protocol names
+var aclProtocols = map[string]string{
+	"IP_TCP":  "tcp",
+	"IP_UDP":  "udp",
+	"IP_ICMP": "icmp",
+	"IP_IGMP": "igmp",
+	"IP_PIM":  "pim",
+	"IP_RSVP": "rsvp",
+	"IP_GRE":  "gre",
+	"IP_AUTH": "ah",
+	"IP_L2TP": "l2tp",
+}
+
+// aclPortProtocols are the protocols (by identity or number) a src-port or dst-port can be matched with
+var aclPortProtocols = map[string]bool{"IP_TCP": true, "IP_UDP": true, "6": true, "17": true}
+
+// aclMatchKeys are the rule properties an ACL entry manages; a rule that differs in any of them is updated
+var aclMatchKeys = []string{"action", "protocol", "src-address", "dst-address", "src-port", "dst-port"}
+
+// ACLToMikrotikCmds maps each acl-set onto a dedicated /ip/firewall/filter (or /ipv6/firewall/filter)
+// chain "acl-<name>" holding one rule per acl-entry in sequence-id order, and each interface's
+// ingress-acl-sets onto jump rules from the input and forward chains.
+//
+// Rules are tagged with an "acl:<set>:<sequence-id>" comment. With device state, unchanged rules are
+// kept, changed rules are set in place (unsetting managed properties the entry no longer has) and new
+// rules are placed before the rule with the next higher sequence-id. An edit merges into the set: a
+// rule is only removed when its acl-entry carries a delete operation. An interface's ingress-acl-sets
+// list is authoritative: jump rules for sets no longer listed are removed.
+func ACLToMikrotikCmds(op string, acl *ACL, state *DeviceState) []string {
+	if acl == nil {
+		return nil
+	}
+	var cmds []string
+	switch op {
+	case "get":
+		cmds = append(cmds, "/ip/firewall/filter/print stats=", "/ipv6/firewall/filter/print stats=")
+	case "set":
+		if acl.ACLSets != nil {
+			for _, set := range acl.ACLSets.ACLSet {
+				cmds = append(cmds, aclSetCmds(&set, state)...)
+			}
+		}
+		if acl.Interfaces != nil {
+			for _, i := range acl.Interfaces.Interface {
+				if i.ID == nil || *i.ID == "" || i.IngressACLSets == nil {
+					continue
+				}
+				bound := map[string]bool{}
+				for _, ingress := range i.IngressACLSets.IngressACLSet {
+					cmds = append(cmds, aclIngressCmds(*i.ID, &ingress, state, bound)...)
+				}
+				cmds = append(cmds, aclUnboundCmds(*i.ID, bound, state)...)
+			}
+		}
+	}
+	return cmds
+}
+
+func aclSetCmds(set *ACLSet, state *DeviceState) []string {
+	name, typ := set.key()
+	if name == "" {
+		return nil
+	}
+	menu, ok := aclFilterMenus[typ]
+	if !ok {
+		return []string{fmt.Sprintf("UNSUPPORTED: acl-set %s type %s is not supported on MikroTik", name, typ)}
+	}
+	chain := aclChain(name)
+
+	// Existing rules of this set, keyed by the sequence-id in their comment
+	existing := map[uint32]map[string]string{}
+	for _, row := range state.table(menu) {
+		if row["chain"] != chain {
+			continue
+		}
+		if seq, ok := aclSequenceFromComment(name, row["comment"]); ok {
+			existing[seq] = row
+		}
+	}
+
+	var entries []*ACLEntry
+	if set.ACLEntries != nil {
+		for i := range set.ACLEntries.ACLEntry {
+			if _, ok := set.ACLEntries.ACLEntry[i].sequenceID(); ok {
+				entries = append(entries, &set.ACLEntries.ACLEntry[i])
+			}
+		}
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		sa, _ := entries[a].sequenceID()
+		sb, _ := entries[b].sequenceID()
+		return sa < sb
+	})
+
+	var cmds []string
+	for _, e := range entries {
+		seq, _ := e.sequenceID()
+		if isDeleteOperation(e.Operation) {
+			if row, ok := existing[seq]; ok {
+				cmds = append(cmds, menu+"/remove .id="+row[".id"])
+			} else {
+				cmds = append(cmds, removeCmds(state, menu, "comment", aclEntryComment(name, seq))...)
+			}
+			continue
+		}
+		attrs, err := aclEntryAttrs(e, typ == "ACL_IPV6")
+		if err != nil {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: acl-set %s acl-entry %d: %v", name, seq, err))
+			continue
+		}
+		if row, ok := existing[seq]; ok {
+			if !rowMatches(row, attrs, aclMatchKeys) {
+				cmds = append(cmds, aclUpdateCmds(menu, row, attrs)...)
+			}
+			continue
+		}
+
+		cmd := menu + "/add chain=" + chain + renderAttrs(attrs)
+		cmd += " comment=" + quote(aclEntryComment(name, seq))
+		if next := aclNextRule(existing, seq); next != nil {
+			cmd += " place-before=" + next[".id"]
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds
+}
+
+// aclUpdateCmds sets a changed rule in place, so it keeps its position in the chain, and unsets the
+// managed properties the entry no longer has
+func aclUpdateCmds(menu string, row map[string]string, attrs []attr) []string {
+	cmds := []string{menu + "/set .id=" + row[".id"] + renderAttrs(attrs)}
+	set := map[string]bool{}
+	for _, a := range attrs {
+		set[a.key] = true
+	}
+	for _, k := range aclMatchKeys {
+		if !set[k] && row[k] != "" {
+			cmds = append(cmds, menu+"/unset numbers="+row[".id"]+" value-name="+k)
+		}
+	}
+	return cmds
+}
+
+// aclNextRule returns the existing rule with the lowest sequence-id above seq
+func aclNextRule(existing map[uint32]map[string]string, seq uint32) map[string]string {
+	var next map[string]string
+	var nextSeq uint32
+	for s, row := range existing {
+		if s > seq && (next == nil || s < nextSeq) {
+			next, nextSeq = row, s
+		}
+	}
+	return next
+}
+
+func aclEntryAttrs(e *ACLEntry, v6 bool) ([]attr, error) {
+	if e.Actions == nil || e.Actions.Config == nil || e.Actions.Config.ForwardingAction == nil {
+		return nil, fmt.Errorf("missing forwarding-action")
+	}
+	action, ok := aclForwardingActions[identity(*e.Actions.Config.ForwardingAction)]
+	if !ok {
+		return nil, fmt.Errorf("forwarding-action %s is not supported", *e.Actions.Config.ForwardingAction)
+	}
+	attrs := []attr{{"action", action}}
+
+	match := e.IPv4
+	if v6 {
+		match = e.IPv6
+	}
+	if match != nil && match.Config != nil {
+		cfg := match.Config
+		if cfg.Protocol != nil {
+			proto, ok := aclProtocols[identity(*cfg.Protocol)]
+			if !ok {
+				proto = *cfg.Protocol // numeric protocol
+			}
+			if v6 && proto == "icmp" {
+				proto = "icmpv6"
+			}
+			attrs = append(attrs, attr{"protocol", proto})
+		}
+		if cfg.SourceAddress != nil && *cfg.SourceAddress != "" {
+			attrs = append(attrs, attr{"src-address", *cfg.SourceAddress})
+		}
+		if cfg.DestinationAddress != nil && *cfg.DestinationAddress != "" {
+			attrs = append(attrs, attr{"dst-address", *cfg.DestinationAddress})
+		}
+	}
+	if e.Transport != nil && e.Transport.Config != nil {
+		for _, port := range []struct {
+			key  string
+			port *string
+		}{{"src-port", e.Transport.Config.SourcePort}, {"dst-port", e.Transport.Config.DestinationPort}} {
+			p := aclPort(port.port)
+			if p == "" {
+				continue
+			}
+			// RouterOS only matches ports together with a protocol that has them
+			if match == nil || match.Config == nil || match.Config.Protocol == nil || !aclPortProtocols[identity(*match.Config.Protocol)] {
+				return nil, fmt.Errorf("%s requires protocol IP_TCP or IP_UDP", port.key)
+			}
+			attrs = append(attrs, attr{port.key, p})
+		}
+	}
+	return attrs, nil
+}
+
+// aclPort renders an OpenConfig port-num-range ("22", "1000..2000" or ANY) as a firewall port
+func aclPort(port *string) string {
+	if port == nil || *port == "" || identity(*port) == "ANY" {
+		return ""
+	}
+	return strings.Replace(*port, "..", "-", 1)
+}
+
+// aclIngressCmds adds jump rules sending traffic received on iface into the ACL set's chain, placed
+// after the chain's fasttrack and established/related rules and ahead of the rest, so the ACL
+// filters new connections before any other rule. Without device state the position is unknown and
+// the jumps are reported as unsupported. The tags of the jump rules are recorded in bound.
+func aclIngressCmds(iface string, ingress *IngressACLSet, state *DeviceState, bound map[string]bool) []string {
+	name, typ := ingress.key()
+	if name == "" {
+		return nil
+	}
+	menu, ok := aclFilterMenus[typ]
+	if !ok {
+		return []string{fmt.Sprintf("UNSUPPORTED: ingress-acl-set %s type %s is not supported on MikroTik", name, typ)}
+	}
+	var cmds []string
+	for _, chain := range []string{"input", "forward"} {
+		tag := aclIngressPrefix(iface) + name + ":" + chain
+		bound[tag] = true
+		if state == nil {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: ingress-acl-set %s on %s %s: placing the jump rule needs device state", name, iface, chain))
+			continue
+		}
+		if state.find(menu, "comment", tag) != nil {
+			continue
+		}
+		cmd := fmt.Sprintf("%s/add chain=%s in-interface=%s action=jump jump-target=%s comment=%s",
+			menu, chain, iface, aclChain(name), quote(tag))
+		if anchor := aclJumpAnchor(state, menu, chain); anchor != nil {
+			cmd += " place-before=" + anchor[".id"]
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds
+}
+
+// aclJumpAnchor returns the rule of chain an ingress jump is placed before: the first static rule
+// after the last fasttrack or established/related rule, or nil to append to an empty chain
+func aclJumpAnchor(state *DeviceState, menu, chain string) map[string]string {
+	var rows []map[string]string
+	for _, row := range state.table(menu) {
+		if row["chain"] == chain && row["dynamic"] != "true" {
+			rows = append(rows, row)
+		}
+	}
+	start := 0
+	for i, row := range rows {
+		conn := row["connection-state"]
+		if row["action"] == "fasttrack-connection" || strings.Contains(conn, "established") || strings.Contains(conn, "related") {
+			start = i + 1
+		}
+	}
+	if start < len(rows) {
+		return rows[start]
+	}
+	return nil
+}
+
+// aclIngressPrefix is the comment prefix of the jump rules bound to iface
+func aclIngressPrefix(iface string) string {
+	return "acl-ingress:" + iface + ":"
+}
+
+// aclUnboundCmds removes the jump rules of iface whose tags are not in bound
+func aclUnboundCmds(iface string, bound map[string]bool, state *DeviceState) []string {
+	var cmds []string
+	for _, menu := range []string{aclFilterMenus["ACL_IPV4"], aclFilterMenus["ACL_IPV6"]} {
+		for _, row := range state.table(menu) {
+			if tag := row["comment"]; strings.HasPrefix(tag, aclIngressPrefix(iface)) && !bound[tag] {
+				cmds = append(cmds, menu+"/remove .id="+row[".id"])
+			}
+		}
+	}
+	return cmds
+}
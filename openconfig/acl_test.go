@@ -0,0 +1,202 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestACL_MikroTikCmd_Set_NoState(t *testing.T) {
+	name, typ := "mgmt", "oc-acl:ACL_IPV4"
+	seq10, seq20 := uint32(10), uint32(20)
+	accept, drop, tcp, src, dport := "ACCEPT", "DROP", "oc-pkt-match-types:IP_TCP", "10.0.0.0/8", "22..23"
+	acl := &ACL{ACLSets: &ACLSets{ACLSet: []ACLSet{{Name: &name, Type: &typ, ACLEntries: &ACLEntries{ACLEntry: []ACLEntry{
+		{SequenceID: &seq20, Actions: &ACLActions{Config: &ACLActionsConfig{ForwardingAction: &drop}}},
+		{
+			SequenceID: &seq10,
+			IPv4:       &ACLIPMatch{Config: &ACLIPMatchConfig{Protocol: &tcp, SourceAddress: &src}},
+			Transport:  &ACLTransport{Config: &ACLTransportConfig{DestinationPort: &dport}},
+			Actions:    &ACLActions{Config: &ACLActionsConfig{ForwardingAction: &accept}},
+		},
+	}}}}}}
+	cmds := ACLToMikrotikCmds("set", acl, nil)
+	expected := []string{
+		`/ip/firewall/filter/add chain=acl-mgmt action=accept protocol=tcp src-address=10.0.0.0/8 dst-port=22-23 comment="acl:mgmt:10"`,
+		`/ip/firewall/filter/add chain=acl-mgmt action=drop comment="acl:mgmt:20"`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestACL_MikroTikCmd_Set_Reconcile(t *testing.T) {
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/ip/firewall/filter": {
+			{".id": "*1", "chain": "input", "action": "accept"},
+			{".id": "*A", "chain": "acl-mgmt", "action": "accept", "protocol": "tcp", "dst-port": "22", "comment": "acl:mgmt:10"},
+			{".id": "*B", "chain": "acl-mgmt", "action": "accept", "protocol": "udp", "comment": "acl:mgmt:30"},
+			{".id": "*C", "chain": "acl-mgmt", "action": "drop", "src-address": "192.0.2.0/24", "comment": "acl:mgmt:40"},
+			{".id": "*D", "chain": "acl-mgmt", "action": "drop", "comment": "acl:mgmt:50"},
+		},
+	}}
+	name, typ := "mgmt", "oc-acl:ACL_IPV4"
+	seq10, seq20, seq30, seq40, seq50 := uint32(10), uint32(20), uint32(30), uint32(40), uint32(50)
+	accept, reject, drop, del := "ACCEPT", "REJECT", "DROP", "delete"
+	tcp, icmp, udp, ssh := "IP_TCP", "IP_ICMP", "IP_UDP", "22"
+	acl := &ACL{ACLSets: &ACLSets{ACLSet: []ACLSet{{Name: &name, Type: &typ, ACLEntries: &ACLEntries{ACLEntry: []ACLEntry{
+		{ // unchanged
+			SequenceID: &seq10,
+			IPv4:       &ACLIPMatch{Config: &ACLIPMatchConfig{Protocol: &tcp}},
+			Transport:  &ACLTransport{Config: &ACLTransportConfig{DestinationPort: &ssh}},
+			Actions:    &ACLActions{Config: &ACLActionsConfig{ForwardingAction: &accept}},
+		},
+		{ // new, lands before 30
+			SequenceID: &seq20,
+			IPv4:       &ACLIPMatch{Config: &ACLIPMatchConfig{Protocol: &icmp}},
+			Actions:    &ACLActions{Config: &ACLActionsConfig{ForwardingAction: &accept}},
+		},
+		{ // changed in place
+			SequenceID: &seq30,
+			IPv4:       &ACLIPMatch{Config: &ACLIPMatchConfig{Protocol: &udp}},
+			Actions:    &ACLActions{Config: &ACLActionsConfig{ForwardingAction: &reject}},
+		},
+		{ // src-address removed
+			SequenceID: &seq40,
+			Actions:    &ACLActions{Config: &ACLActionsConfig{ForwardingAction: &drop}},
+		},
+		{SequenceID: &seq50, Operation: &del},
+	}}}}}}
+	cmds := ACLToMikrotikCmds("set", acl, state)
+	expected := []string{
+		`/ip/firewall/filter/add chain=acl-mgmt action=accept protocol=icmp comment="acl:mgmt:20" place-before=*B`,
+		"/ip/firewall/filter/set .id=*B action=reject protocol=udp",
+		"/ip/firewall/filter/set .id=*C action=drop",
+		"/ip/firewall/filter/unset numbers=*C value-name=src-address",
+		"/ip/firewall/filter/remove .id=*D",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+
+	// An edit merges into the set: entries it leaves out are kept
+	acl.ACLSets.ACLSet[0].ACLEntries.ACLEntry = acl.ACLSets.ACLSet[0].ACLEntries.ACLEntry[:1]
+	if cmds := ACLToMikrotikCmds("set", acl, state); len(cmds) != 0 {
+		t.Errorf("expected no commands, got %v", cmds)
+	}
+}
+
+func TestACL_MikroTikCmd_Set_PortWithoutProtocol(t *testing.T) {
+	name, typ, accept, icmp, ssh := "mgmt", "ACL_IPV4", "ACCEPT", "IP_ICMP", "22"
+	seq10, seq20 := uint32(10), uint32(20)
+	acl := &ACL{ACLSets: &ACLSets{ACLSet: []ACLSet{{Name: &name, Type: &typ, ACLEntries: &ACLEntries{ACLEntry: []ACLEntry{
+		{
+			SequenceID: &seq10,
+			Transport:  &ACLTransport{Config: &ACLTransportConfig{DestinationPort: &ssh}},
+			Actions:    &ACLActions{Config: &ACLActionsConfig{ForwardingAction: &accept}},
+		},
+		{
+			SequenceID: &seq20,
+			IPv4:       &ACLIPMatch{Config: &ACLIPMatchConfig{Protocol: &icmp}},
+			Transport:  &ACLTransport{Config: &ACLTransportConfig{SourcePort: &ssh}},
+			Actions:    &ACLActions{Config: &ACLActionsConfig{ForwardingAction: &accept}},
+		},
+	}}}}}}
+	cmds := ACLToMikrotikCmds("set", acl, nil)
+	expected := []string{
+		"UNSUPPORTED: acl-set mgmt acl-entry 10: dst-port requires protocol IP_TCP or IP_UDP",
+		"UNSUPPORTED: acl-set mgmt acl-entry 20: src-port requires protocol IP_TCP or IP_UDP",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestACL_MikroTikCmd_Set_IngressJump(t *testing.T) {
+	iface, set := "ether1", "mgmt"
+	acl := &ACL{Interfaces: &ACLInterfaces{Interface: []ACLInterface{{
+		ID:             &iface,
+		IngressACLSets: &IngressACLSets{IngressACLSet: []IngressACLSet{{SetName: &set}}},
+	}}}}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/ip/firewall/filter": {
+			{".id": "*0", "chain": "input", "action": "accept", "connection-state": "established,related,untracked"},
+			{".id": "*1", "chain": "input", "action": "accept"},
+			{".id": "*2", "chain": "forward", "action": "jump", "comment": "acl-ingress:ether1:mgmt:forward"},
+			{".id": "*3", "chain": "input", "action": "jump", "comment": "acl-ingress:ether1:old:input"},
+			{".id": "*4", "chain": "input", "action": "jump", "comment": "acl-ingress:ether2:old:input"},
+		},
+	}}
+	cmds := ACLToMikrotikCmds("set", acl, state)
+	expected := []string{
+		`/ip/firewall/filter/add chain=input in-interface=ether1 action=jump jump-target=acl-mgmt comment="acl-ingress:ether1:mgmt:input" place-before=*1`,
+		"/ip/firewall/filter/remove .id=*3",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+
+	// The jump goes after fasttrack and established/related rules; a chain holding only those gets it appended
+	state = &DeviceState{Tables: map[string][]map[string]string{
+		"/ip/firewall/filter": {
+			{".id": "*5", "chain": "forward", "action": "passthrough", "dynamic": "true"},
+			{".id": "*6", "chain": "forward", "action": "fasttrack-connection", "connection-state": "established,related"},
+			{".id": "*7", "chain": "forward", "action": "accept", "connection-state": "established,related,untracked"},
+			{".id": "*8", "chain": "forward", "action": "drop", "connection-state": "invalid"},
+			{".id": "*9", "chain": "input", "action": "accept", "connection-state": "established,related"},
+		},
+	}}
+	cmds = ACLToMikrotikCmds("set", acl, state)
+	expected = []string{
+		`/ip/firewall/filter/add chain=input in-interface=ether1 action=jump jump-target=acl-mgmt comment="acl-ingress:ether1:mgmt:input"`,
+		`/ip/firewall/filter/add chain=forward in-interface=ether1 action=jump jump-target=acl-mgmt comment="acl-ingress:ether1:mgmt:forward" place-before=*8`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+
+	// Without state the position of the jumps is unknown
+	cmds = ACLToMikrotikCmds("set", acl, nil)
+	expected = []string{
+		"UNSUPPORTED: ingress-acl-set mgmt on ether1 input: placing the jump rule needs device state",
+		"UNSUPPORTED: ingress-acl-set mgmt on ether1 forward: placing the jump rule needs device state",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestACLEntriesStateFromMikrotik(t *testing.T) {
+	entries := ACLEntriesStateFromMikrotik("mgmt", []map[string]string{
+		{"chain": "acl-mgmt", "comment": "acl:mgmt:10", "packets": "42", "bytes": "6300"},
+		{"chain": "input", "comment": "acl:mgmt:10", "packets": "1", "bytes": "1"},
+	})
+	if len(entries.ACLEntry) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries.ACLEntry))
+	}
+	st := entries.ACLEntry[0].State
+	if *st.SequenceID != 10 || *st.MatchedPackets != 42 || *st.MatchedOctets != 6300 {
+		t.Errorf("unexpected state %+v", st)
+	}
+}
+
+func TestACLGetFromMikrotik_AllSets(t *testing.T) {
+	replies := Replies{
+		"/ip/firewall/filter/print stats=": {
+			{".id": "*1", "chain": "acl-mgmt", "comment": "acl:mgmt:10", "packets": "5", "bytes": "300"},
+			{".id": "*2", "chain": "forward", "comment": "other"},
+		},
+		"/ipv6/firewall/filter/print stats=": {
+			{".id": "*3", "chain": "acl-v6", "comment": "acl:v6:20", "packets": "1", "bytes": "80"},
+		},
+	}
+	acl := ACLGetFromMikrotik(`<acl/>`, replies)
+	if len(acl.ACLSets.ACLSet) != 2 {
+		t.Fatalf("expected 2 acl-sets, got %d", len(acl.ACLSets.ACLSet))
+	}
+	v4, v6 := acl.ACLSets.ACLSet[0], acl.ACLSets.ACLSet[1]
+	if *v4.Name != "mgmt" || *v4.Type != "ACL_IPV4" || *v4.ACLEntries.ACLEntry[0].State.MatchedPackets != 5 {
+		t.Errorf("unexpected IPv4 set %+v", v4)
+	}
+	if *v6.Name != "v6" || *v6.Type != "ACL_IPV6" || *v6.ACLEntries.ACLEntry[0].State.MatchedOctets != 80 {
+		t.Errorf("unexpected IPv6 set %+v", v6)
+	}
+}
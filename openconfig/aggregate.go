@@ -0,0 +1,23 @@
+package openconfig
+
+// InterfaceAggregation represents the openconfig-if-aggregate augmentation of interfaces/interface
+type InterfaceAggregation struct {
+	Config *AggregationConfig `xml:"config"`
+}
+
+type AggregationConfig struct {
+	LagType  *string `xml:"lag-type"` // LACP or STATIC
+	MinLinks *uint16 `xml:"min-links"`
+}
+
+// aggregateMembers returns the interfaces whose ethernet/config/aggregate-id references lag
+func aggregateMembers(ifs *Interfaces, lag string) []string {
+	var members []string
+	for _, i := range ifs.Interface {
+		if i.Ethernet != nil && i.Ethernet.Config != nil && i.Ethernet.Config.AggregateID != nil &&
+			*i.Ethernet.Config.AggregateID == lag && i.name() != "" {
+			members = append(members, i.name())
+		}
+	}
+	return members
+}
@@ -0,0 +1,57 @@
+package openconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lagTypeToBondingMode maps openconfig-if-aggregate lag-type onto /interface/bonding mode
+var lagTypeToBondingMode = map[string]string{
+	"LACP":   "802.3ad",
+	"STATIC": "balance-xor",
+}
+
+// handleInterfaceAggregation maps aggregation/config and member ethernet/config/aggregate-id
+// onto /interface/bonding entries with their slaves list. With device state, existing bonds are
+// updated in place and left alone when already configured.
+func handleInterfaceAggregation(op string, ifs *Interfaces, state *DeviceState) []string {
+	var cmds []string
+	listed := false
+	for _, i := range ifs.Interface {
+		if i.Aggregation == nil {
+			continue
+		}
+		switch op {
+		case "get":
+			if !listed {
+				cmds = append(cmds, "/interface/bonding/print")
+				listed = true
+			}
+		case "set":
+			cfg := i.Aggregation.Config
+			if cfg == nil || i.name() == "" {
+				continue
+			}
+			var attrs []attr
+			if cfg.LagType != nil {
+				mode, ok := lagTypeToBondingMode[identity(*cfg.LagType)]
+				if !ok {
+					cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: interfaces/interface/aggregation lag-type %s is not supported on MikroTik", *cfg.LagType))
+					continue
+				}
+				attrs = append(attrs, attr{"mode", mode})
+			}
+			if members := aggregateMembers(ifs, i.name()); len(members) > 0 {
+				attrs = append(attrs, attr{"slaves", strings.Join(members, ",")})
+			}
+			if cfg.MinLinks != nil {
+				attrs = append(attrs, attr{"min-links", fmt.Sprint(*cfg.MinLinks)})
+			}
+			if row := state.find("/interface/bonding", "name", i.name()); row != nil && rowMatches(row, attrs, attrKeys(attrs)) {
+				continue
+			}
+			cmds = append(cmds, upsertCmd(state, "/interface/bonding", "name", i.name(), renderAttrs(attrs)))
+		}
+	}
+	return cmds
+}
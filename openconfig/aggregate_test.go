@@ -0,0 +1,65 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInterfaceAggregation_MikroTikCmd_Set_LACP(t *testing.T) {
+	bond, e1, e2 := "bond1", "ether1", "ether2"
+	lacp := "oc-if-aggregate:LACP"
+	var minLinks uint16 = 1
+	ifs := &Interfaces{Interface: []Interface{
+		{Name: &bond, Aggregation: &InterfaceAggregation{Config: &AggregationConfig{LagType: &lacp, MinLinks: &minLinks}}},
+		{Name: &e1, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{AggregateID: &bond}}},
+		{Name: &e2, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{AggregateID: &bond}}},
+	}}
+	cmds := InterfacesToMikrotikCmdsRegistry("set", ifs, nil)
+	expected := []string{"/interface/bonding/add name=bond1 mode=802.3ad slaves=ether1,ether2 min-links=1"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestInterfaceAggregation_MikroTikCmd_Set_Static(t *testing.T) {
+	bond := "bond2"
+	static := "STATIC"
+	ifs := &Interfaces{Interface: []Interface{
+		{Name: &bond, Aggregation: &InterfaceAggregation{Config: &AggregationConfig{LagType: &static}}},
+	}}
+	cmds := InterfacesToMikrotikCmdsRegistry("set", ifs, nil)
+	expected := []string{"/interface/bonding/add name=bond2 mode=balance-xor"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestInterfaceAggregation_MikroTikCmd_Set_Reconcile(t *testing.T) {
+	bond1, bond2, e1 := "bond1", "bond2", "ether1"
+	lacp := "LACP"
+	var minLinks uint16 = 1
+	ifs := &Interfaces{Interface: []Interface{
+		{Name: &bond1, Aggregation: &InterfaceAggregation{Config: &AggregationConfig{LagType: &lacp, MinLinks: &minLinks}}},
+		{Name: &bond2, Aggregation: &InterfaceAggregation{Config: &AggregationConfig{LagType: &lacp}}},
+		{Name: &e1, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{AggregateID: &bond2}}},
+	}}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/interface/bonding": {
+			{".id": "*1", "name": "bond1", "mode": "802.3ad", "slaves": "", "min-links": "1"},
+			{".id": "*2", "name": "bond2", "mode": "802.3ad", "slaves": "ether2", "min-links": "0"},
+		},
+	}}
+	cmds := InterfacesToMikrotikCmdsRegistry("set", ifs, state)
+	expected := []string{"/interface/bonding/set .id=*2 mode=802.3ad slaves=ether1"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestInterfacesGetToMikrotikCmds_Aggregation(t *testing.T) {
+	cmds := InterfacesGetToMikrotikCmds(`<interfaces><interface><name>bond1</name><aggregation/></interface><interface><name>bond2</name><aggregation/></interface></interfaces>`)
+	expected := []string{"/interface/bonding/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
@@ -0,0 +1,90 @@
+package openconfig
+
+// BGP represents protocols/protocol[BGP]/bgp
+type BGP struct {
+	Global    *BGPGlobal    `xml:"global"`
+	Neighbors *BGPNeighbors `xml:"neighbors"`
+}
+
+type BGPGlobal struct {
+	Config   *BGPGlobalConfig `xml:"config"`
+	AfiSafis *BGPAfiSafis     `xml:"afi-safis"`
+}
+
+type BGPGlobalConfig struct {
+	AS       *uint32 `xml:"as"`
+	RouterID *string `xml:"router-id"`
+}
+
+type BGPNeighbors struct {
+	Neighbor []BGPNeighbor `xml:"neighbor"`
+}
+
+type BGPNeighbor struct {
+	NeighborAddress *string            `xml:"neighbor-address"`
+	Config          *BGPNeighborConfig `xml:"config"`
+	State           *BGPNeighborState  `xml:"state"`
+	AfiSafis        *BGPAfiSafis       `xml:"afi-safis"`
+	ApplyPolicy     *ApplyPolicy       `xml:"apply-policy"`
+}
+
+type BGPNeighborConfig struct {
+	NeighborAddress *string `xml:"neighbor-address"`
+	PeerAS          *uint32 `xml:"peer-as"`
+	Description     *string `xml:"description"`
+	Enabled         *bool   `xml:"enabled"`
+	AuthPassword    *string `xml:"auth-password"`
+}
+
+type BGPNeighborState struct {
+	NeighborAddress        *string `xml:"neighbor-address"`
+	PeerAS                 *uint32 `xml:"peer-as"`
+	SessionState           *string `xml:"session-state"` // ESTABLISHED, IDLE, ...
+	EstablishedTransitions *uint64 `xml:"established-transitions"`
+}
+
+type BGPAfiSafis struct {
+	AfiSafi []BGPAfiSafi `xml:"afi-safi"`
+}
+
+type BGPAfiSafi struct {
+	AfiSafiName *string           `xml:"afi-safi-name"`
+	Config      *BGPAfiSafiConfig `xml:"config"`
+	State       *BGPAfiSafiState  `xml:"state"`
+}
+
+type BGPAfiSafiConfig struct {
+	AfiSafiName *string `xml:"afi-safi-name"`
+	Enabled     *bool   `xml:"enabled"`
+}
+
+type BGPAfiSafiState struct {
+	AfiSafiName *string      `xml:"afi-safi-name"`
+	Prefixes    *BGPPrefixes `xml:"prefixes"`
+}
+
+type BGPPrefixes struct {
+	Received *uint32 `xml:"received"`
+}
+
+// address returns the neighbor key, falling back to config/neighbor-address
+func (n *BGPNeighbor) address() string {
+	if n.NeighborAddress != nil {
+		return *n.NeighborAddress
+	}
+	if n.Config != nil && n.Config.NeighborAddress != nil {
+		return *n.Config.NeighborAddress
+	}
+	return ""
+}
+
+// name returns the afi-safi key, falling back to config/afi-safi-name
+func (a *BGPAfiSafi) name() string {
+	if a.AfiSafiName != nil {
+		return identity(*a.AfiSafiName)
+	}
+	if a.Config != nil && a.Config.AfiSafiName != nil {
+		return identity(*a.Config.AfiSafiName)
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package openconfig
+
+import (
+	"strconv"
+	"strings"
+)
+
+// BGPNeighborsStateFromMikrotik maps /routing/bgp/session/print rows onto neighbor state.
+// RouterOS only lists sessions it is running and does not count established transitions,
+// so session-state is ESTABLISHED or ACTIVE and established-transitions is left unset.
+// prefix-count is reported per session, so it is only attributed to an afi-safi when a
+// single address family was negotiated.
+func BGPNeighborsStateFromMikrotik(sessions []map[string]string) *BGPNeighbors {
+	neighbors := &BGPNeighbors{}
+	for _, s := range sessions {
+		addr := s["remote.address"]
+		if addr == "" {
+			continue
+		}
+		sessionState := "ACTIVE"
+		if s["established"] == "true" {
+			sessionState = "ESTABLISHED"
+		}
+		n := BGPNeighbor{
+			NeighborAddress: &addr,
+			State:           &BGPNeighborState{NeighborAddress: &addr, SessionState: &sessionState},
+		}
+		if as, err := strconv.ParseUint(s["remote.as"], 10, 32); err == nil {
+			peerAS := uint32(as)
+			n.State.PeerAS = &peerAS
+		}
+
+		afis := strings.Split(s["remote.afi"], ",")
+		if count, err := strconv.ParseUint(s["prefix-count"], 10, 32); err == nil && len(afis) == 1 {
+			for name, af := range bgpAddressFamilies {
+				if af != afis[0] {
+					continue
+				}
+				afiName, received := name, uint32(count)
+				n.AfiSafis = &BGPAfiSafis{AfiSafi: []BGPAfiSafi{{
+					AfiSafiName: &afiName,
+					State:       &BGPAfiSafiState{AfiSafiName: &afiName, Prefixes: &BGPPrefixes{Received: &received}},
+				}}}
+			}
+		}
+		neighbors.Neighbor = append(neighbors.Neighbor, n)
+	}
+	return neighbors
+}
@@ -0,0 +1,169 @@
+package openconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bgpAddressFamilies maps OpenConfig afi-safi-name identities onto RouterOS 7 address-families
+var bgpAddressFamilies = map[string]string{
+	"IPV4_UNICAST":       "ip",
+	"IPV6_UNICAST":       "ipv6",
+	"L3VPN_IPV4_UNICAST": "vpnv4",
+	"L2VPN_VPLS":         "l2vpn",
+}
+
+// handleBGP maps bgp/global/config onto a /routing/bgp/template and each neighbor onto a
+// /routing/bgp/connection named after its neighbor-address that uses the template. The
+// DEFAULT instance uses the built-in "default" template; L3VRF instances get a template
+// of their own and their connections are bound to the VRF. A neighbor's apply-policy is
+// wired to the connection's input.filter and output.filter chains. An L3VRF template is created
+// even when only neighbors are edited, and every connection gets a local.role, falling back to
+// the AS numbers already on the device when the edit does not carry them. Only RouterOS 7 is supported.
+func handleBGP(op string, ni *NetworkInstance, p *Protocol, state *DeviceState) []string {
+	switch op {
+	case "get":
+		return []string{"/routing/bgp/connection/print", "/routing/bgp/session/print"}
+	case "set":
+		if p.BGP == nil {
+			return nil
+		}
+		if state.majorVersion() < 7 {
+			return []string{"UNSUPPORTED: protocol BGP is only supported on RouterOS 7 (/routing/bgp/connection)"}
+		}
+
+		var cmds []string
+		template := "default"
+		if !ni.isDefault() {
+			template = ni.name()
+		}
+
+		var localAS *uint32
+		if g := p.BGP.Global; g != nil {
+			args := ""
+			if g.Config != nil {
+				localAS = g.Config.AS
+				if g.Config.AS != nil {
+					args += fmt.Sprintf(" as=%d", *g.Config.AS)
+				}
+				if g.Config.RouterID != nil && *g.Config.RouterID != "" {
+					args += " router-id=" + *g.Config.RouterID
+				}
+			}
+			if afs := bgpAddressFamilyList(g.AfiSafis); afs != "" {
+				args += " address-families=" + afs
+			}
+			if args != "" && template == "default" {
+				cmds = append(cmds, "/routing/bgp/template/set numbers=default"+args)
+			} else if args != "" {
+				cmds = append(cmds, upsertCmd(state, "/routing/bgp/template", "name", template, args))
+			}
+		}
+		if template != "default" && !bgpHasTemplateArgs(p.BGP.Global) && state.find("/routing/bgp/template", "name", template) == nil {
+			// Connections of an L3VRF instance name its template, which must exist first
+			cmds = append(cmds, "/routing/bgp/template/add name="+template)
+		}
+		if localAS == nil {
+			localAS = bgpStateAS(state.find("/routing/bgp/template", "name", template), "as")
+		}
+
+		if p.BGP.Neighbors != nil {
+			for _, n := range p.BGP.Neighbors.Neighbor {
+				if n.address() == "" {
+					continue
+				}
+				args := bgpConnectionArgs(&n, template, localAS, state.find("/routing/bgp/connection", "name", n.address()))
+				if !ni.isDefault() {
+					args += " vrf=" + ni.name()
+				}
+				if ap := n.ApplyPolicy; ap != nil && ap.Config != nil {
+					in, inCmds := bgpPolicyChain(state, "bgp-in-"+n.address(), ap.Config.ImportPolicy, ap.Config.DefaultImportPolicy)
+					out, outCmds := bgpPolicyChain(state, "bgp-out-"+n.address(), ap.Config.ExportPolicy, ap.Config.DefaultExportPolicy)
+					cmds = append(cmds, inCmds...)
+					cmds = append(cmds, outCmds...)
+					if in != "" {
+						args += " input.filter=" + in
+					}
+					if out != "" {
+						args += " output.filter=" + out
+					}
+				}
+				cmds = append(cmds, upsertCmd(state, "/routing/bgp/connection", "name", n.address(), args))
+			}
+		}
+		return cmds
+	}
+	return nil
+}
+
+// bgpConnectionArgs renders a neighbor's connection args. RouterOS 7 requires local.role on
+// every connection, so it is always set: ibgp when the local and peer AS match, ebgp otherwise.
+// Either AS falls back to the existing template or connection row when the config omits it.
+func bgpConnectionArgs(n *BGPNeighbor, template string, localAS *uint32, row map[string]string) string {
+	args := " remote.address=" + n.address() + " templates=" + template
+	peerAS := bgpStateAS(row, "remote.as")
+	if n.Config != nil && n.Config.PeerAS != nil {
+		peerAS = n.Config.PeerAS
+		args += fmt.Sprintf(" remote.as=%d", *peerAS)
+	}
+	role := "ebgp"
+	if localAS != nil && peerAS != nil && *localAS == *peerAS {
+		role = "ibgp"
+	}
+	args += " local.role=" + role
+	if cfg := n.Config; cfg != nil {
+		if cfg.Description != nil && *cfg.Description != "" {
+			args += " comment=" + quote(*cfg.Description)
+		}
+		if cfg.Enabled != nil {
+			args += " disabled=" + yesNo(!*cfg.Enabled)
+		}
+		if cfg.AuthPassword != nil && *cfg.AuthPassword != "" {
+			args += " tcp-md5-key=" + quote(*cfg.AuthPassword)
+		}
+	}
+	if afs := bgpAddressFamilyList(n.AfiSafis); afs != "" {
+		args += " address-families=" + afs
+	}
+	return args
+}
+
+// bgpAddressFamilyList renders the enabled afi-safis as a RouterOS address-families list.
+// Families RouterOS has no equivalent for are skipped.
+func bgpAddressFamilyList(afis *BGPAfiSafis) string {
+	if afis == nil {
+		return ""
+	}
+	var families []string
+	for _, a := range afis.AfiSafi {
+		if a.Config != nil && a.Config.Enabled != nil && !*a.Config.Enabled {
+			continue
+		}
+		if af, ok := bgpAddressFamilies[a.name()]; ok {
+			families = append(families, af)
+		}
+	}
+	return strings.Join(families, ",")
+}
+
+// bgpHasTemplateArgs reports whether global config renders any template args
+func bgpHasTemplateArgs(g *BGPGlobal) bool {
+	if g == nil {
+		return false
+	}
+	if g.Config != nil && (g.Config.AS != nil || (g.Config.RouterID != nil && *g.Config.RouterID != "")) {
+		return true
+	}
+	return bgpAddressFamilyList(g.AfiSafis) != ""
+}
+
+// bgpStateAS reads an AS number from a device row, nil when the row or value is missing
+func bgpStateAS(row map[string]string, key string) *uint32 {
+	as, err := strconv.ParseUint(row[key], 10, 32)
+	if err != nil {
+		return nil
+	}
+	v := uint32(as)
+	return &v
+}
@@ -0,0 +1,114 @@
+package openconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBGP_MikroTikCmd_Set(t *testing.T) {
+	var localAS, peerAS uint32 = 65000, 65001
+	routerID, addr, desc, pw := "192.0.2.1", "198.51.100.1", "transit", "s3cret"
+	enabled, v6Enabled := true, false
+	v4, v6 := "IPV4_UNICAST", "IPV6_UNICAST"
+	bgp := &BGP{
+		Global: &BGPGlobal{Config: &BGPGlobalConfig{AS: &localAS, RouterID: &routerID}},
+		Neighbors: &BGPNeighbors{Neighbor: []BGPNeighbor{{
+			NeighborAddress: &addr,
+			Config:          &BGPNeighborConfig{PeerAS: &peerAS, Description: &desc, Enabled: &enabled, AuthPassword: &pw},
+			AfiSafis: &BGPAfiSafis{AfiSafi: []BGPAfiSafi{
+				{AfiSafiName: &v4},
+				{AfiSafiName: &v6, Config: &BGPAfiSafiConfig{Enabled: &v6Enabled}},
+			}},
+		}}},
+	}
+	name, id := "DEFAULT", "oc-pol-types:BGP"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name:      &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{Identifier: &id, BGP: bgp}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, nil)
+	expected := []string{
+		"/routing/bgp/template/set numbers=default as=65000 router-id=192.0.2.1",
+		`/routing/bgp/connection/add name=198.51.100.1 remote.address=198.51.100.1 templates=default remote.as=65001 local.role=ebgp comment="transit" disabled=no tcp-md5-key="s3cret" address-families=ip`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestBGP_MikroTikCmd_Set_ExistingConnection(t *testing.T) {
+	addr := "198.51.100.1"
+	enabled := false
+	bgp := &BGP{Neighbors: &BGPNeighbors{Neighbor: []BGPNeighbor{{NeighborAddress: &addr, Config: &BGPNeighborConfig{Enabled: &enabled}}}}}
+	state := &DeviceState{Version: "7.12", Tables: map[string][]map[string]string{
+		"/routing/bgp/template":   {{".id": "*1", "name": "default", "as": "65000"}},
+		"/routing/bgp/connection": {{".id": "*A", "name": "198.51.100.1", "remote.as": "65000"}},
+	}}
+	name, id := "DEFAULT", "oc-pol-types:BGP"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name:      &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{Identifier: &id, BGP: bgp}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, state)
+	expected := []string{"/routing/bgp/connection/set .id=*A remote.address=198.51.100.1 templates=default local.role=ibgp disabled=yes"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestBGP_MikroTikCmd_Set_VRFNeighborOnly(t *testing.T) {
+	var peerAS uint32 = 65010
+	addr := "203.0.113.2"
+	vrf, typ, id := "cust-a", "oc-ni-types:L3VRF", "oc-pol-types:BGP"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name:   &vrf,
+		Config: &NetworkInstanceConfig{Name: &vrf, Type: &typ},
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{Identifier: &id, BGP: &BGP{
+			Neighbors: &BGPNeighbors{Neighbor: []BGPNeighbor{{NeighborAddress: &addr, Config: &BGPNeighborConfig{PeerAS: &peerAS}}}},
+		}}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, &DeviceState{Version: "7.12"})
+	expected := []string{
+		"/routing/table/add name=cust-a fib",
+		"/routing/bgp/template/add name=cust-a",
+		"/routing/bgp/connection/add name=203.0.113.2 remote.address=203.0.113.2 templates=cust-a remote.as=65010 local.role=ebgp vrf=cust-a",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestBGP_MikroTikCmd_Set_RouterOS6(t *testing.T) {
+	var as uint32 = 65000
+	bgp := &BGP{Global: &BGPGlobal{Config: &BGPGlobalConfig{AS: &as}}}
+	name, id := "DEFAULT", "oc-pol-types:BGP"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name:      &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{Identifier: &id, BGP: bgp}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, &DeviceState{Version: "6.49.10"})
+	if len(cmds) != 1 || !strings.HasPrefix(cmds[0], "UNSUPPORTED:") {
+		t.Errorf("expected an UNSUPPORTED marker, got %v", cmds)
+	}
+}
+
+func TestBGPNeighborsStateFromMikrotik(t *testing.T) {
+	neighbors := BGPNeighborsStateFromMikrotik([]map[string]string{
+		{"name": "198.51.100.1-1", "remote.address": "198.51.100.1", "remote.as": "65001", "established": "true", "remote.afi": "ip", "prefix-count": "812345"},
+		{"name": "203.0.113.1-1", "remote.address": "203.0.113.1", "remote.as": "65002"},
+	})
+	if len(neighbors.Neighbor) != 2 {
+		t.Fatalf("expected 2 neighbors, got %d", len(neighbors.Neighbor))
+	}
+	up, down := neighbors.Neighbor[0], neighbors.Neighbor[1]
+	if *up.State.SessionState != "ESTABLISHED" || *up.State.PeerAS != 65001 {
+		t.Errorf("unexpected state %+v", up.State)
+	}
+	if up.AfiSafis == nil || *up.AfiSafis.AfiSafi[0].AfiSafiName != "IPV4_UNICAST" || *up.AfiSafis.AfiSafi[0].State.Prefixes.Received != 812345 {
+		t.Errorf("unexpected afi-safis %+v", up.AfiSafis)
+	}
+	if *down.State.SessionState != "ACTIVE" || down.AfiSafis != nil {
+		t.Errorf("unexpected state %+v", down.State)
+	}
+}
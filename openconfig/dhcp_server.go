@@ -0,0 +1,155 @@
+package openconfig
+
+import "strings"
+
+// DHCPServer is a vendor augmentation (OpenConfig has no DHCP server model) describing address
+// pools, DHCP servers, the networks they hand out and static leases. Leases holds the active
+// lease table and is read-only.
+//
+//	<dhcp-server>
+//	  <pools><pool><name>lan</name><config><ranges>192.168.88.10-192.168.88.254</ranges></config></pool></pools>
+//	  <servers><server><name>lan</name><config><interface>bridge</interface><pool>lan</pool></config></server></servers>
+//	  <networks><network><prefix>192.168.88.0/24</prefix><config><gateway>192.168.88.1</gateway></config></network></networks>
+//	  <static-leases><static-lease><mac-address>...</mac-address><config><address>...</address></config></static-lease></static-leases>
+//	</dhcp-server>
+type DHCPServer struct {
+	Pools        *DHCPPools        `xml:"pools"`
+	Servers      *DHCPServers      `xml:"servers"`
+	Networks     *DHCPNetworks     `xml:"networks"`
+	StaticLeases *DHCPStaticLeases `xml:"static-leases"`
+	Leases       *DHCPLeases       `xml:"leases"`
+}
+
+type DHCPPools struct {
+	Pool []DHCPPool `xml:"pool"`
+}
+
+type DHCPPool struct {
+	// Operation is the NETCONF edit-config operation attribute, e.g. "delete"
+	Operation *string         `xml:"operation,attr"`
+	Name      *string         `xml:"name"`
+	Config    *DHCPPoolConfig `xml:"config"`
+}
+
+type DHCPPoolConfig struct {
+	Name   *string  `xml:"name"`
+	Ranges []string `xml:"ranges"` // "first-last" address ranges
+}
+
+type DHCPServers struct {
+	Server []DHCPServerInstance `xml:"server"`
+}
+
+type DHCPServerInstance struct {
+	// Operation is the NETCONF edit-config operation attribute, e.g. "delete"
+	Operation *string                   `xml:"operation,attr"`
+	Name      *string                   `xml:"name"`
+	Config    *DHCPServerInstanceConfig `xml:"config"`
+}
+
+type DHCPServerInstanceConfig struct {
+	Name      *string `xml:"name"`
+	Interface *string `xml:"interface"`
+	Pool      *string `xml:"pool"`
+	LeaseTime *string `xml:"lease-time"` // RouterOS duration, e.g. "30m" or "1d"
+	Enabled   *bool   `xml:"enabled"`
+}
+
+type DHCPNetworks struct {
+	Network []DHCPNetwork `xml:"network"`
+}
+
+type DHCPNetwork struct {
+	// Operation is the NETCONF edit-config operation attribute, e.g. "delete"
+	Operation *string            `xml:"operation,attr"`
+	Prefix    *string            `xml:"prefix"`
+	Config    *DHCPNetworkConfig `xml:"config"`
+}
+
+type DHCPNetworkConfig struct {
+	Prefix     *string  `xml:"prefix"`
+	Gateway    *string  `xml:"gateway"`
+	DNSServers []string `xml:"dns-servers"`
+	Domain     *string  `xml:"domain"`
+}
+
+type DHCPStaticLeases struct {
+	StaticLease []DHCPStaticLease `xml:"static-lease"`
+}
+
+type DHCPStaticLease struct {
+	// Operation is the NETCONF edit-config operation attribute, e.g. "delete"
+	Operation  *string                `xml:"operation,attr"`
+	MACAddress *string                `xml:"mac-address"`
+	Config     *DHCPStaticLeaseConfig `xml:"config"`
+}
+
+type DHCPStaticLeaseConfig struct {
+	MACAddress  *string `xml:"mac-address"`
+	Address     *string `xml:"address"`
+	Server      *string `xml:"server"` // limits the lease to one server; any server when unset
+	Description *string `xml:"description"`
+}
+
+type DHCPLeases struct {
+	Lease []DHCPLease `xml:"lease"`
+}
+
+type DHCPLease struct {
+	Address *string         `xml:"address"`
+	State   *DHCPLeaseState `xml:"state"`
+}
+
+type DHCPLeaseState struct {
+	Address      *string `xml:"address"`
+	MACAddress   *string `xml:"mac-address"`
+	HostName     *string `xml:"host-name"`
+	Server       *string `xml:"server"`
+	Status       *string `xml:"status"`
+	ExpiresAfter *string `xml:"expires-after"`
+	Static       *bool   `xml:"static"`
+}
+
+// name returns the pool key, falling back to config/name
+func (p *DHCPPool) name() string {
+	if p.Name != nil {
+		return *p.Name
+	}
+	if p.Config != nil && p.Config.Name != nil {
+		return *p.Config.Name
+	}
+	return ""
+}
+
+// name returns the server key, falling back to config/name
+func (s *DHCPServerInstance) name() string {
+	if s.Name != nil {
+		return *s.Name
+	}
+	if s.Config != nil && s.Config.Name != nil {
+		return *s.Config.Name
+	}
+	return ""
+}
+
+// prefix returns the network key, falling back to config/prefix
+func (n *DHCPNetwork) prefix() string {
+	if n.Prefix != nil {
+		return *n.Prefix
+	}
+	if n.Config != nil && n.Config.Prefix != nil {
+		return *n.Config.Prefix
+	}
+	return ""
+}
+
+// mac returns the static lease key, falling back to config/mac-address, in the upper case RouterOS prints
+func (l *DHCPStaticLease) mac() string {
+	if l.MACAddress != nil {
+		return strings.ToUpper(*l.MACAddress)
+	}
+	if l.Config != nil && l.Config.MACAddress != nil {
+		return strings.ToUpper(*l.Config.MACAddress)
+	}
+	return ""
+}
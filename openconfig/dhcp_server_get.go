@@ -0,0 +1,106 @@
+package openconfig
+
+import "strings"
+
+// DHCPServerGetToMikrotikCmds parses the filter XML and dispatches to the DHCP server handler for get operations.
+func DHCPServerGetToMikrotikCmds(filterXML string) []string {
+	var f struct {
+		DHCPServer *DHCPServer `xml:"dhcp-server"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	return DHCPServerToMikrotikCmds("get", f.DHCPServer, nil)
+}
+
+// DHCPServerFromMikrotik reverse-maps /ip/pool, /ip/dhcp-server, /ip/dhcp-server/network and
+// /ip/dhcp-server/lease rows into the DHCP server model. Static leases become static-leases
+// config; every lease the device reports as bound, static or dynamic, is listed under leases state.
+func DHCPServerFromMikrotik(pools, servers, networks, leases []map[string]string) *DHCPServer {
+	dhcp := &DHCPServer{
+		Pools:        &DHCPPools{},
+		Servers:      &DHCPServers{},
+		Networks:     &DHCPNetworks{},
+		StaticLeases: &DHCPStaticLeases{},
+		Leases:       &DHCPLeases{},
+	}
+	for _, row := range pools {
+		name := row["name"]
+		if name == "" {
+			continue
+		}
+		cfg := &DHCPPoolConfig{Name: &name}
+		if row["ranges"] != "" {
+			cfg.Ranges = strings.Split(row["ranges"], ",")
+		}
+		dhcp.Pools.Pool = append(dhcp.Pools.Pool, DHCPPool{Name: &name, Config: cfg})
+	}
+	for _, row := range servers {
+		name := row["name"]
+		if name == "" {
+			continue
+		}
+		enabled := row["disabled"] != "true" && row["disabled"] != "yes"
+		dhcp.Servers.Server = append(dhcp.Servers.Server, DHCPServerInstance{Name: &name, Config: &DHCPServerInstanceConfig{
+			Name:      &name,
+			Interface: optional(row, "interface"),
+			Pool:      optional(row, "address-pool"),
+			LeaseTime: optional(row, "lease-time"),
+			Enabled:   &enabled,
+		}})
+	}
+	for _, row := range networks {
+		prefix := row["address"]
+		if prefix == "" {
+			continue
+		}
+		cfg := &DHCPNetworkConfig{Prefix: &prefix, Gateway: optional(row, "gateway"), Domain: optional(row, "domain")}
+		if row["dns-server"] != "" {
+			cfg.DNSServers = strings.Split(row["dns-server"], ",")
+		}
+		dhcp.Networks.Network = append(dhcp.Networks.Network, DHCPNetwork{Prefix: &prefix, Config: cfg})
+	}
+	for _, row := range leases {
+		mac, address := row["mac-address"], row["address"]
+		static := row["dynamic"] != "true"
+		if static && mac != "" {
+			cfg := &DHCPStaticLeaseConfig{MACAddress: &mac, Address: optional(row, "address"), Description: optional(row, "comment")}
+			if server := row["server"]; server != "" && server != "all" {
+				cfg.Server = &server
+			}
+			dhcp.StaticLeases.StaticLease = append(dhcp.StaticLeases.StaticLease, DHCPStaticLease{MACAddress: &mac, Config: cfg})
+		}
+		if row["status"] != "bound" || address == "" {
+			continue
+		}
+		state := &DHCPLeaseState{
+			Address:      &address,
+			MACAddress:   optional(row, "active-mac-address"),
+			HostName:     optional(row, "host-name"),
+			Server:       optional(row, "active-server"),
+			Status:       optional(row, "status"),
+			ExpiresAfter: optional(row, "expires-after"),
+			Static:       &static,
+		}
+		if state.MACAddress == nil {
+			state.MACAddress = optional(row, "mac-address")
+		}
+		if state.Server == nil {
+			state.Server = optional(row, "server")
+		}
+		dhcp.Leases.Lease = append(dhcp.Leases.Lease, DHCPLease{Address: &address, State: state})
+	}
+	return dhcp
+}
+
+// DHCPServerGetFromMikrotik builds the DHCP server vendor augmentation from the pool, server, network
+// and lease rows
+func DHCPServerGetFromMikrotik(filterXML string, replies Replies) *DHCPServer {
+	var f struct {
+		DHCPServer *DHCPServer `xml:"dhcp-server"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	if f.DHCPServer == nil {
+		return nil
+	}
+	return DHCPServerFromMikrotik(replies["/ip/pool/print"], replies["/ip/dhcp-server/print"],
+		replies["/ip/dhcp-server/network/print"], replies["/ip/dhcp-server/lease/print"])
+}
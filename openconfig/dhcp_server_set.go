@@ -0,0 +1,167 @@
+package openconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DHCPServerToMikrotikCmds maps the DHCP server vendor augmentation onto RouterOS: pools onto
+// /ip/pool (by name), servers onto /ip/dhcp-server (by name), networks onto /ip/dhcp-server/network
+// (by address) and static leases onto /ip/dhcp-server/lease (by mac-address). Existing entries are
+// updated in place and entries carrying an nc:operation="delete" attribute are removed; entries not
+// listed are left alone. Pools are configured before the servers that hand them out.
+func DHCPServerToMikrotikCmds(op string, dhcp *DHCPServer, state *DeviceState) []string {
+	if dhcp == nil {
+		return nil
+	}
+	switch op {
+	case "get":
+		return []string{"/ip/pool/print", "/ip/dhcp-server/print", "/ip/dhcp-server/network/print", "/ip/dhcp-server/lease/print"}
+	case "set":
+		var cmds []string
+		if dhcp.Pools != nil {
+			for _, p := range dhcp.Pools.Pool {
+				cmds = append(cmds, dhcpPoolCmds(&p, state)...)
+			}
+		}
+		if dhcp.Servers != nil {
+			for _, s := range dhcp.Servers.Server {
+				cmds = append(cmds, dhcpServerCmds(&s, state)...)
+			}
+		}
+		if dhcp.Networks != nil {
+			for _, n := range dhcp.Networks.Network {
+				cmds = append(cmds, dhcpNetworkCmds(&n, state)...)
+			}
+		}
+		if dhcp.StaticLeases != nil {
+			for _, l := range dhcp.StaticLeases.StaticLease {
+				cmds = append(cmds, dhcpStaticLeaseCmds(&l, state)...)
+			}
+		}
+		return cmds
+	}
+	return nil
+}
+
+func dhcpPoolCmds(p *DHCPPool, state *DeviceState) []string {
+	name := p.name()
+	if name == "" {
+		return nil
+	}
+	if isDeleteOperation(p.Operation) {
+		return removeCmds(state, "/ip/pool", "name", name)
+	}
+	if p.Config == nil || len(p.Config.Ranges) == 0 {
+		return nil
+	}
+	ranges := make([]string, len(p.Config.Ranges))
+	for i, r := range p.Config.Ranges {
+		ranges[i] = strings.Replace(r, "..", "-", 1)
+	}
+	return []string{upsertCmd(state, "/ip/pool", "name", name, " ranges="+strings.Join(ranges, ","))}
+}
+
+func dhcpServerCmds(s *DHCPServerInstance, state *DeviceState) []string {
+	name := s.name()
+	if name == "" {
+		return nil
+	}
+	if isDeleteOperation(s.Operation) {
+		return removeCmds(state, "/ip/dhcp-server", "name", name)
+	}
+	if s.Config == nil {
+		return nil
+	}
+	existing := state.find("/ip/dhcp-server", "name", name)
+	cfg := s.Config
+	var attrs []attr
+	if cfg.Interface != nil && *cfg.Interface != "" {
+		attrs = append(attrs, attr{"interface", *cfg.Interface})
+	} else if existing == nil {
+		return []string{fmt.Sprintf("UNSUPPORTED: dhcp-server server %s: missing interface", name)}
+	}
+	if cfg.Pool != nil && *cfg.Pool != "" {
+		attrs = append(attrs, attr{"address-pool", *cfg.Pool})
+	}
+	if cfg.LeaseTime != nil && *cfg.LeaseTime != "" {
+		attrs = append(attrs, attr{"lease-time", *cfg.LeaseTime})
+	}
+	if cfg.Enabled != nil {
+		attrs = append(attrs, attr{"disabled", yesNo(!*cfg.Enabled)})
+	} else if existing == nil {
+		// RouterOS adds DHCP servers disabled
+		attrs = append(attrs, attr{"disabled", "no"})
+	}
+	if len(attrs) == 0 {
+		return nil
+	}
+	return []string{upsertCmd(state, "/ip/dhcp-server", "name", name, renderAttrs(attrs))}
+}
+
+func dhcpNetworkCmds(n *DHCPNetwork, state *DeviceState) []string {
+	prefix := n.prefix()
+	if prefix == "" {
+		return nil
+	}
+	if isDeleteOperation(n.Operation) {
+		return removeCmds(state, "/ip/dhcp-server/network", "address", prefix)
+	}
+	if n.Config == nil {
+		return nil
+	}
+	var attrs []attr
+	if n.Config.Gateway != nil && *n.Config.Gateway != "" {
+		attrs = append(attrs, attr{"gateway", *n.Config.Gateway})
+	}
+	if len(n.Config.DNSServers) > 0 {
+		attrs = append(attrs, attr{"dns-server", strings.Join(n.Config.DNSServers, ",")})
+	}
+	if n.Config.Domain != nil && *n.Config.Domain != "" {
+		attrs = append(attrs, attr{"domain", *n.Config.Domain})
+	}
+	return []string{upsertCmd(state, "/ip/dhcp-server/network", "address", prefix, renderAttrs(attrs))}
+}
+
+func dhcpStaticLeaseCmds(l *DHCPStaticLease, state *DeviceState) []string {
+	mac := l.mac()
+	if mac == "" {
+		return nil
+	}
+	existing := dhcpStaticLease(state, mac)
+	if isDeleteOperation(l.Operation) {
+		if existing != nil {
+			return []string{"/ip/dhcp-server/lease/remove .id=" + existing[".id"]}
+		}
+		if state == nil {
+			return []string{"/ip/dhcp-server/lease/remove [find mac-address=" + mac + " dynamic=no]"}
+		}
+		return nil
+	}
+	if l.Config == nil || l.Config.Address == nil || *l.Config.Address == "" {
+		return []string{fmt.Sprintf("UNSUPPORTED: dhcp-server static-lease %s: missing address", mac)}
+	}
+	args := " address=" + *l.Config.Address
+	server := "all"
+	if l.Config.Server != nil && *l.Config.Server != "" {
+		server = *l.Config.Server
+	}
+	args += " server=" + quote(server)
+	if l.Config.Description != nil {
+		args += " comment=" + quote(*l.Config.Description)
+	}
+	if existing != nil {
+		return []string{"/ip/dhcp-server/lease/set .id=" + existing[".id"] + args}
+	}
+	return []string{"/ip/dhcp-server/lease/add mac-address=" + mac + args}
+}
+
+// dhcpStaticLease returns the static lease for mac, ignoring dynamic leases handed out to it
+func dhcpStaticLease(state *DeviceState, mac string) map[string]string {
+	for _, row := range state.table("/ip/dhcp-server/lease") {
+		if strings.EqualFold(row["mac-address"], mac) && row["dynamic"] != "true" {
+			return row
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package openconfig
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+const testDHCPServerXML = `<dhcp-server>
+  <pools><pool><name>lan</name><config><ranges>192.168.88.10-192.168.88.254</ranges></config></pool></pools>
+  <servers><server><name>lan</name><config><interface>bridge</interface><pool>lan</pool><lease-time>1d</lease-time></config></server></servers>
+  <networks><network><prefix>192.168.88.0/24</prefix><config>
+    <gateway>192.168.88.1</gateway><dns-servers>192.168.88.1</dns-servers><dns-servers>9.9.9.9</dns-servers><domain>branch.example.net</domain>
+  </config></network></networks>
+  <static-leases><static-lease><mac-address>aa:bb:cc:00:11:22</mac-address><config><address>192.168.88.5</address><description>printer</description></config></static-lease></static-leases>
+</dhcp-server>`
+
+func TestDHCPServer_MikroTikCmd_Set_NoState(t *testing.T) {
+	var dhcp DHCPServer
+	if err := xml.Unmarshal([]byte(testDHCPServerXML), &dhcp); err != nil {
+		t.Fatal(err)
+	}
+	cmds := DHCPServerToMikrotikCmds("set", &dhcp, nil)
+	expected := []string{
+		"/ip/pool/add name=lan ranges=192.168.88.10-192.168.88.254",
+		"/ip/dhcp-server/add name=lan interface=bridge address-pool=lan lease-time=1d disabled=no",
+		"/ip/dhcp-server/network/add address=192.168.88.0/24 gateway=192.168.88.1 dns-server=192.168.88.1,9.9.9.9 domain=branch.example.net",
+		`/ip/dhcp-server/lease/add mac-address=AA:BB:CC:00:11:22 address=192.168.88.5 server="all" comment="printer"`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestDHCPServer_MikroTikCmd_Set_WithState(t *testing.T) {
+	var dhcp DHCPServer
+	if err := xml.Unmarshal([]byte(testDHCPServerXML), &dhcp); err != nil {
+		t.Fatal(err)
+	}
+	old, del := "old", "delete"
+	dhcp.Pools.Pool = append(dhcp.Pools.Pool, DHCPPool{Name: &old, Operation: &del})
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/ip/pool":                {{".id": "*1", "name": "lan"}, {".id": "*2", "name": "old"}},
+		"/ip/dhcp-server":         {{".id": "*3", "name": "lan", "interface": "bridge"}},
+		"/ip/dhcp-server/network": {{".id": "*4", "address": "192.168.88.0/24"}},
+		"/ip/dhcp-server/lease": {
+			{".id": "*5", "mac-address": "AA:BB:CC:00:11:22", "dynamic": "true"},
+			{".id": "*6", "mac-address": "AA:BB:CC:00:11:22", "dynamic": "false"},
+		},
+	}}
+	cmds := DHCPServerToMikrotikCmds("set", &dhcp, state)
+	expected := []string{
+		"/ip/pool/set .id=*1 ranges=192.168.88.10-192.168.88.254",
+		"/ip/pool/remove .id=*2",
+		"/ip/dhcp-server/set .id=*3 interface=bridge address-pool=lan lease-time=1d",
+		"/ip/dhcp-server/network/set .id=*4 gateway=192.168.88.1 dns-server=192.168.88.1,9.9.9.9 domain=branch.example.net",
+		`/ip/dhcp-server/lease/set .id=*6 address=192.168.88.5 server="all" comment="printer"`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestDHCPServerGetToMikrotikCmds(t *testing.T) {
+	cmds := DHCPServerGetToMikrotikCmds(`<dhcp-server><leases/></dhcp-server>`)
+	expected := []string{"/ip/pool/print", "/ip/dhcp-server/print", "/ip/dhcp-server/network/print", "/ip/dhcp-server/lease/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestDHCPServerFromMikrotik(t *testing.T) {
+	dhcp := DHCPServerFromMikrotik(
+		[]map[string]string{{"name": "lan", "ranges": "192.168.88.10-192.168.88.99,192.168.88.200-192.168.88.254"}},
+		[]map[string]string{{"name": "lan", "interface": "bridge", "address-pool": "lan", "lease-time": "1d", "disabled": "false"}},
+		[]map[string]string{{"address": "192.168.88.0/24", "gateway": "192.168.88.1", "dns-server": "192.168.88.1,9.9.9.9"}},
+		[]map[string]string{
+			{"mac-address": "AA:BB:CC:00:11:22", "address": "192.168.88.5", "server": "all", "dynamic": "false", "status": "waiting"},
+			{"mac-address": "AA:BB:CC:00:11:33", "address": "192.168.88.50", "active-server": "lan", "host-name": "laptop",
+				"dynamic": "true", "status": "bound", "expires-after": "23h10m"},
+		},
+	)
+	if len(dhcp.Pools.Pool[0].Config.Ranges) != 2 || *dhcp.Servers.Server[0].Config.Interface != "bridge" || !*dhcp.Servers.Server[0].Config.Enabled {
+		t.Errorf("unexpected pools/servers: %+v %+v", dhcp.Pools.Pool[0].Config, dhcp.Servers.Server[0].Config)
+	}
+	if dns := dhcp.Networks.Network[0].Config.DNSServers; !reflect.DeepEqual(dns, []string{"192.168.88.1", "9.9.9.9"}) {
+		t.Errorf("unexpected dns-servers: %v", dns)
+	}
+	if len(dhcp.StaticLeases.StaticLease) != 1 || *dhcp.StaticLeases.StaticLease[0].MACAddress != "AA:BB:CC:00:11:22" ||
+		dhcp.StaticLeases.StaticLease[0].Config.Server != nil {
+		t.Errorf("unexpected static leases: %+v", dhcp.StaticLeases.StaticLease)
+	}
+	if len(dhcp.Leases.Lease) != 1 {
+		t.Fatalf("expected 1 active lease, got %d", len(dhcp.Leases.Lease))
+	}
+	lease := dhcp.Leases.Lease[0].State
+	if *lease.Address != "192.168.88.50" || *lease.MACAddress != "AA:BB:CC:00:11:33" || *lease.Server != "lan" ||
+		*lease.HostName != "laptop" || *lease.Static {
+		t.Errorf("unexpected lease state: %+v", lease)
+	}
+}
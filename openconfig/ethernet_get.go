@@ -0,0 +1,31 @@
+package openconfig
+
+import "strings"
+
+// EthernetStateFromMikrotik maps an /interface/ethernet/monitor once reply onto ethernet/state,
+// translating the negotiated rate (e.g. "1Gbps") back to its OpenConfig speed identity.
+func EthernetStateFromMikrotik(monitor map[string]string) *EthernetState {
+	state := &EthernetState{}
+	if v, ok := monitor["auto-negotiation"]; ok {
+		// RouterOS reports "done" once negotiation has completed and "disabled" otherwise
+		autoneg := v != "disabled"
+		state.AutoNegotiate = &autoneg
+	}
+	if rate, ok := monitor["rate"]; ok {
+		for identity, s := range ethernetSpeeds {
+			if s.rate == rate {
+				speed := identity
+				state.NegotiatedPortSpeed = &speed
+				break
+			}
+		}
+	}
+	if v, ok := monitor["full-duplex"]; ok {
+		duplex := "HALF"
+		if strings.EqualFold(v, "true") || strings.EqualFold(v, "yes") {
+			duplex = "FULL"
+		}
+		state.NegotiatedDuplexMode = &duplex
+	}
+	return state
+}
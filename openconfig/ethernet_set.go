@@ -0,0 +1,166 @@
+package openconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ethernetSpeed holds the RouterOS spellings of an OpenConfig port-speed identity. RouterOS 6 uses
+// a fixed speed with auto-negotiation off and an advertise prefix (suffixed with -full or -half)
+// with it on; RouterOS 7.12+ uses the same link mode for both. Link modes depend on the port's
+// media, so linkModes lists one per media, BASE-T first; only the BASE-T modes of rates with
+// halfDuplex are suffixed with -full or -half. Empty spellings have no RouterOS 6 equivalent.
+// rate is the negotiated rate reported by /interface/ethernet/monitor.
+type ethernetSpeed struct {
+	rate        string
+	v6Speed     string
+	v6Advertise string
+	linkModes   []string
+	halfDuplex  bool
+}
+
+var ethernetSpeeds = map[string]ethernetSpeed{
+	"SPEED_10MB":   {"10Mbps", "10Mbps", "10M", []string{"10M-baseT"}, true},
+	"SPEED_100MB":  {"100Mbps", "100Mbps", "100M", []string{"100M-baseT"}, true},
+	"SPEED_1GB":    {"1Gbps", "1Gbps", "1000M", []string{"1G-baseT", "1G-baseX"}, true},
+	"SPEED_2500MB": {"2.5Gbps", "", "2500M", []string{"2.5G-baseT", "2.5G-baseX"}, false},
+	"SPEED_5GB":    {"5Gbps", "", "5000M", []string{"5G-baseT"}, false},
+	"SPEED_10GB":   {"10Gbps", "10Gbps", "10000M", []string{"10G-baseT", "10G-baseSR-LR", "10G-baseCR"}, false},
+	"SPEED_25GB":   {"25Gbps", "", "", []string{"25G-baseSR-LR", "25G-baseCR"}, false},
+	"SPEED_40GB":   {"40Gbps", "", "", []string{"40G-baseSR4-LR4", "40G-baseCR4"}, false},
+	"SPEED_100GB":  {"100Gbps", "", "", []string{"100G-baseSR4-LR4", "100G-baseCR4"}, false},
+}
+
+// linkMode picks the RouterOS 7 link mode for a port: the one the port lists among its supported
+// or advertised modes in device state, or without such a list the BASE-T mode for an "ether" port
+// (MikroTik's name for its RJ45 ports) or the speed's only mode. It returns "" when the media is unknown.
+func (s *ethernetSpeed) linkMode(name string, row map[string]string) string {
+	if listed := strings.Trim(row["supported"]+","+row["advertise"], ","); listed != "" {
+		for _, mode := range s.linkModes {
+			for _, l := range strings.Split(listed, ",") {
+				if l == mode || strings.HasPrefix(l, mode+"-") {
+					return mode
+				}
+			}
+		}
+		return ""
+	}
+	if strings.HasPrefix(name, "ether") && strings.HasSuffix(s.linkModes[0], "baseT") {
+		return s.linkModes[0]
+	}
+	if len(s.linkModes) == 1 {
+		return s.linkModes[0]
+	}
+	return ""
+}
+
+// handleInterfaceEthernet maps ethernet/config auto-negotiate, port-speed, duplex-mode and
+// enable-flow-control onto /interface/ethernet. With auto-negotiation on, the speed and duplex
+// become the single advertised mode; with it off they are forced via speed (and full-duplex on
+// RouterOS 6). RouterOS 7 uses the link mode spellings introduced in 7.12.
+func handleInterfaceEthernet(op string, ifs *Interfaces, state *DeviceState) []string {
+	var cmds []string
+	listed := false
+	for _, i := range ifs.Interface {
+		if i.Ethernet == nil {
+			continue
+		}
+		switch op {
+		case "get":
+			if i.Ethernet.Config != nil && !listed {
+				cmds = append(cmds, "/interface/ethernet/print")
+				listed = true
+			}
+			if i.Ethernet.State != nil && i.name() != "" {
+				cmds = append(cmds, "/interface/ethernet/monitor numbers="+i.name()+" once=")
+			}
+		case "set":
+			cfg := i.Ethernet.Config
+			if cfg == nil || i.name() == "" {
+				continue
+			}
+			args, unsupported := ethernetSetArgs(i.name(), cfg, state.majorVersion(), state.find("/interface/ethernet", "name", i.name()))
+			cmds = append(cmds, unsupported...)
+			if args != "" {
+				cmds = append(cmds, "/interface/ethernet/set numbers="+i.name()+args)
+			}
+		}
+	}
+	return cmds
+}
+
+// ethernetSetArgs renders the ethernet config for the given RouterOS major version and the port's
+// /interface/ethernet row, with UNSUPPORTED markers for settings that version cannot express
+func ethernetSetArgs(name string, cfg *EthernetConfig, major int, row map[string]string) (string, []string) {
+	var unsupported []string
+	args := ""
+	full := cfg.DuplexMode == nil || identity(*cfg.DuplexMode) != "HALF"
+	duplex := "full"
+	if !full {
+		duplex = "half"
+	}
+
+	var speed *ethernetSpeed
+	if cfg.PortSpeed != nil {
+		s, ok := ethernetSpeeds[identity(*cfg.PortSpeed)]
+		if !ok {
+			return "", []string{fmt.Sprintf("UNSUPPORTED: interfaces/interface/ethernet port-speed %s is not supported on MikroTik", *cfg.PortSpeed)}
+		}
+		if major < 7 && s.v6Advertise == "" {
+			return "", []string{fmt.Sprintf("UNSUPPORTED: interfaces/interface/ethernet port-speed %s is not supported on RouterOS 6", *cfg.PortSpeed)}
+		}
+		if !full && !s.halfDuplex {
+			return "", []string{fmt.Sprintf("UNSUPPORTED: interfaces/interface %s ethernet port-speed %s has no half duplex mode", name, *cfg.PortSpeed)}
+		}
+		speed = &s
+	}
+	linkMode := ""
+	if speed != nil && major >= 7 {
+		linkMode = speed.linkMode(name, row)
+		switch {
+		case linkMode == "":
+			return "", []string{fmt.Sprintf("UNSUPPORTED: interfaces/interface %s ethernet port-speed %s: the port's media is unknown, so no link mode can be chosen", name, *cfg.PortSpeed)}
+		case speed.halfDuplex && strings.HasSuffix(linkMode, "baseT"):
+			linkMode += "-" + duplex
+		case !full:
+			return "", []string{fmt.Sprintf("UNSUPPORTED: interfaces/interface %s ethernet port-speed %s has no half duplex mode on %s", name, *cfg.PortSpeed, linkMode)}
+		}
+	}
+
+	autoneg := cfg.AutoNegotiate == nil || *cfg.AutoNegotiate
+	if cfg.AutoNegotiate != nil {
+		args += " auto-negotiation=" + yesNo(*cfg.AutoNegotiate)
+	}
+	switch {
+	case autoneg && speed != nil && major >= 7:
+		args += " advertise=" + linkMode
+	case autoneg && speed != nil:
+		args += " advertise=" + speed.v6Advertise + "-" + duplex
+	case autoneg && cfg.DuplexMode != nil:
+		// Without a port-speed there is no single mode to advertise the duplex with
+		unsupported = append(unsupported, fmt.Sprintf("UNSUPPORTED: interfaces/interface %s ethernet duplex-mode without port-speed is ignored with auto-negotiation on", name))
+	case speed != nil && major >= 7:
+		args += " speed=" + linkMode
+	case speed != nil && speed.v6Speed == "":
+		unsupported = append(unsupported, fmt.Sprintf("UNSUPPORTED: interfaces/interface %s ethernet port-speed %s cannot be forced on RouterOS 6", name, *cfg.PortSpeed))
+	case speed != nil:
+		args += " speed=" + speed.v6Speed
+		if cfg.DuplexMode != nil {
+			args += " full-duplex=" + yesNo(full)
+		}
+	case cfg.DuplexMode != nil && major >= 7:
+		// RouterOS 7.12+ carries the duplex in the speed link mode only
+		unsupported = append(unsupported, fmt.Sprintf("UNSUPPORTED: interfaces/interface %s ethernet duplex-mode without port-speed is not supported on RouterOS 7", name))
+	case cfg.DuplexMode != nil:
+		args += " full-duplex=" + yesNo(full)
+	}
+
+	if cfg.EnableFlowControl != nil {
+		flow := "off"
+		if *cfg.EnableFlowControl {
+			flow = "on"
+		}
+		args += " rx-flow-control=" + flow + " tx-flow-control=" + flow
+	}
+	return args, unsupported
+}
@@ -0,0 +1,140 @@
+package openconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInterfaceEthernet_MikroTikCmd_Set_AutoNegotiate(t *testing.T) {
+	name := "ether1"
+	autoneg, flow := true, false
+	speed := "oc-eth:SPEED_1GB"
+	ifs := &Interfaces{Interface: []Interface{{Name: &name, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{
+		AutoNegotiate: &autoneg, PortSpeed: &speed, EnableFlowControl: &flow,
+	}}}}}
+	cmds := InterfacesToMikrotikCmdsRegistry("set", ifs, nil)
+	expected := []string{"/interface/ethernet/set numbers=ether1 auto-negotiation=yes advertise=1G-baseT-full rx-flow-control=off tx-flow-control=off"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestInterfaceEthernet_MikroTikCmd_Set_Forced(t *testing.T) {
+	name := "ether2"
+	autoneg := false
+	speed, duplex := "SPEED_100MB", "HALF"
+	ifs := &Interfaces{Interface: []Interface{{Name: &name, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{
+		AutoNegotiate: &autoneg, PortSpeed: &speed, DuplexMode: &duplex,
+	}}}}}
+	cmds := InterfacesToMikrotikCmdsRegistry("set", ifs, nil)
+	expected := []string{"/interface/ethernet/set numbers=ether2 auto-negotiation=no speed=100M-baseT-half"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestInterfaceEthernet_MikroTikCmd_Set_RouterOS6(t *testing.T) {
+	e1, e2, e3 := "ether1", "ether2", "sfp1"
+	autoneg, forced := true, false
+	gig, fast, fourty := "SPEED_1GB", "SPEED_100MB", "SPEED_40GB"
+	half := "HALF"
+	ifs := &Interfaces{Interface: []Interface{
+		{Name: &e1, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{AutoNegotiate: &autoneg, PortSpeed: &gig}}},
+		{Name: &e2, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{AutoNegotiate: &forced, PortSpeed: &fast, DuplexMode: &half}}},
+		{Name: &e3, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{PortSpeed: &fourty}}},
+	}}
+	cmds := InterfacesToMikrotikCmdsRegistry("set", ifs, &DeviceState{Version: "6.49.10"})
+	expected := []string{
+		"/interface/ethernet/set numbers=ether1 auto-negotiation=yes advertise=1000M-full",
+		"/interface/ethernet/set numbers=ether2 auto-negotiation=no speed=100Mbps full-duplex=no",
+		"UNSUPPORTED: interfaces/interface/ethernet port-speed SPEED_40GB is not supported on RouterOS 6",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestInterfaceEthernet_MikroTikCmd_Set_LinkModeByMedia(t *testing.T) {
+	sfp, copper, unknown, fast := "sfp-sfpplus1", "ether1", "sfp2", "sfp3"
+	autoneg := false
+	tenGig, gig, half := "SPEED_10GB", "SPEED_1GB", "HALF"
+	ifs := &Interfaces{Interface: []Interface{
+		{Name: &sfp, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{AutoNegotiate: &autoneg, PortSpeed: &tenGig}}},
+		{Name: &copper, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{AutoNegotiate: &autoneg, PortSpeed: &tenGig}}},
+		{Name: &unknown, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{AutoNegotiate: &autoneg, PortSpeed: &gig}}},
+		{Name: &fast, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{AutoNegotiate: &autoneg, PortSpeed: &gig, DuplexMode: &half}}},
+	}}
+	state := &DeviceState{Version: "7.15.3", Tables: map[string][]map[string]string{
+		"/interface/ethernet": {
+			{".id": "*1", "name": "sfp-sfpplus1", "advertise": "1G-baseX,10G-baseSR-LR"},
+			{".id": "*3", "name": "sfp3", "advertise": "1G-baseX"},
+		},
+	}}
+	cmds := InterfacesToMikrotikCmdsRegistry("set", ifs, state)
+	expected := []string{
+		"/interface/ethernet/set numbers=sfp-sfpplus1 auto-negotiation=no speed=10G-baseSR-LR",
+		"/interface/ethernet/set numbers=ether1 auto-negotiation=no speed=10G-baseT",
+		"UNSUPPORTED: interfaces/interface sfp2 ethernet port-speed SPEED_1GB: the port's media is unknown, so no link mode can be chosen",
+		"UNSUPPORTED: interfaces/interface sfp3 ethernet port-speed SPEED_1GB has no half duplex mode on 1G-baseX",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestInterfaceEthernet_MikroTikCmd_Set_DuplexWithoutSpeed(t *testing.T) {
+	name := "ether4"
+	autoneg, flow := true, true
+	duplex := "FULL"
+	ifs := &Interfaces{Interface: []Interface{{Name: &name, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{
+		AutoNegotiate: &autoneg, DuplexMode: &duplex, EnableFlowControl: &flow,
+	}}}}}
+	cmds := InterfacesToMikrotikCmdsRegistry("set", ifs, nil)
+	expected := []string{
+		"UNSUPPORTED: interfaces/interface ether4 ethernet duplex-mode without port-speed is ignored with auto-negotiation on",
+		"/interface/ethernet/set numbers=ether4 auto-negotiation=yes rx-flow-control=on tx-flow-control=on",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestInterfaceEthernet_MikroTikCmd_Set_UnsupportedSpeed(t *testing.T) {
+	name := "ether3"
+	speed := "SPEED_400GB"
+	ifs := &Interfaces{Interface: []Interface{{Name: &name, Ethernet: &InterfaceEthernet{Config: &EthernetConfig{PortSpeed: &speed}}}}}
+	cmds := InterfacesToMikrotikCmdsRegistry("set", ifs, nil)
+	if len(cmds) != 1 || !strings.HasPrefix(cmds[0], "UNSUPPORTED") {
+		t.Errorf("expected an UNSUPPORTED marker, got %v", cmds)
+	}
+}
+
+func TestInterfacesGetToMikrotikCmds_EthernetState(t *testing.T) {
+	cmds := InterfacesGetToMikrotikCmds(`<interfaces><interface><name>ether1</name><ethernet><state/></ethernet></interface></interfaces>`)
+	expected := []string{"/interface/ethernet/monitor numbers=ether1 once="}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestEthernetStateFromMikrotik(t *testing.T) {
+	state := EthernetStateFromMikrotik(map[string]string{"status": "link-ok", "auto-negotiation": "done", "rate": "1Gbps", "full-duplex": "true"})
+	if state.AutoNegotiate == nil || !*state.AutoNegotiate {
+		t.Errorf("expected auto-negotiate true, got %v", state.AutoNegotiate)
+	}
+	if state.NegotiatedPortSpeed == nil || *state.NegotiatedPortSpeed != "SPEED_1GB" {
+		t.Errorf("expected SPEED_1GB, got %v", state.NegotiatedPortSpeed)
+	}
+	if state.NegotiatedDuplexMode == nil || *state.NegotiatedDuplexMode != "FULL" {
+		t.Errorf("expected FULL, got %v", state.NegotiatedDuplexMode)
+	}
+}
+
+func TestInterfacesGetFromMikrotik_EthernetState(t *testing.T) {
+	replies := Replies{"/interface/ethernet/monitor numbers=ether1 once=": {{"rate": "10Gbps", "full-duplex": "true"}}}
+	ifs := InterfacesGetFromMikrotik(`<interfaces><interface><name>ether1</name><ethernet><state/></ethernet></interface></interfaces>`, replies)
+	if len(ifs.Interface) != 1 || *ifs.Interface[0].Ethernet.State.NegotiatedPortSpeed != "SPEED_10GB" {
+		t.Errorf("unexpected interfaces %+v", ifs.Interface)
+	}
+}
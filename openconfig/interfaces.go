@@ -0,0 +1,41 @@
+package openconfig
+
+// Interfaces represents the openconfig-interfaces top-level container
+type Interfaces struct {
+	Interface []Interface `xml:"interface"`
+}
+
+type Interface struct {
+	Name        *string               `xml:"name"`
+	Ethernet    *InterfaceEthernet    `xml:"ethernet"`
+	Aggregation *InterfaceAggregation `xml:"aggregation"`
+	// Add more fields as needed (e.g., subinterfaces)
+}
+
+type InterfaceEthernet struct {
+	Config       *EthernetConfig `xml:"config"`
+	State        *EthernetState  `xml:"state"`
+	SwitchedVLAN *SwitchedVLAN   `xml:"switched-vlan"`
+}
+
+type EthernetConfig struct {
+	AggregateID       *string `xml:"aggregate-id"` // name of the LAG this port is a member of
+	AutoNegotiate     *bool   `xml:"auto-negotiate"`
+	PortSpeed         *string `xml:"port-speed"`  // e.g. SPEED_1GB
+	DuplexMode        *string `xml:"duplex-mode"` // FULL or HALF
+	EnableFlowControl *bool   `xml:"enable-flow-control"`
+}
+
+type EthernetState struct {
+	AutoNegotiate        *bool   `xml:"auto-negotiate"`
+	NegotiatedPortSpeed  *string `xml:"negotiated-port-speed"`
+	NegotiatedDuplexMode *string `xml:"negotiated-duplex-mode"`
+}
+
+// name returns the interface key, which maps directly onto the MikroTik interface name
+func (i *Interface) name() string {
+	if i.Name != nil {
+		return *i.Name
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package openconfig
+
+// InterfacesGetToMikrotikCmds parses the filter XML and dispatches to the interface registry for get operations.
+func InterfacesGetToMikrotikCmds(filterXML string) []string {
+	var f struct {
+		Interfaces *Interfaces `xml:"interfaces"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	return InterfacesToMikrotikCmdsRegistry("get", f.Interfaces, nil)
+}
+
+// InterfacesGetFromMikrotik builds ethernet/state for the named interfaces of a get filter from their
+// /interface/ethernet/monitor replies
+func InterfacesGetFromMikrotik(filterXML string, replies Replies) *Interfaces {
+	var f struct {
+		Interfaces *Interfaces `xml:"interfaces"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	if f.Interfaces == nil {
+		return nil
+	}
+	out := &Interfaces{}
+	for _, i := range f.Interfaces.Interface {
+		if i.Ethernet == nil || i.Ethernet.State == nil || i.name() == "" {
+			continue
+		}
+		monitor := replies.row("/interface/ethernet/monitor numbers=" + i.name() + " once=")
+		if monitor == nil {
+			continue
+		}
+		name := i.name()
+		out.Interface = append(out.Interface, Interface{Name: &name, Ethernet: &InterfaceEthernet{State: EthernetStateFromMikrotik(monitor)}})
+	}
+	return out
+}
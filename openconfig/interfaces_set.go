@@ -0,0 +1,22 @@
+package openconfig
+
+type InterfaceFeatureHandler func(op string, ifs *Interfaces, state *DeviceState) []string
+
+// interfaceFeatureHandlers run in order, so LAGs exist before anything references them
+var interfaceFeatureHandlers = []InterfaceFeatureHandler{
+	handleInterfaceAggregation,
+	handleInterfaceEthernet,
+}
+
+// InterfacesToMikrotikCmdsRegistry dispatches to interface feature handlers for get/set.
+// Each handler checks whether its subtree is present on any interface.
+func InterfacesToMikrotikCmdsRegistry(op string, ifs *Interfaces, state *DeviceState) []string {
+	if ifs == nil {
+		return nil
+	}
+	var cmds []string
+	for _, handler := range interfaceFeatureHandlers {
+		cmds = append(cmds, handler(op, ifs, state)...)
+	}
+	return cmds
+}
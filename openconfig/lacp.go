@@ -0,0 +1,39 @@
+package openconfig
+
+// LACP represents the openconfig-lacp top-level container. Only operational
+// state is modelled; LACP itself is configured through interface aggregation.
+type LACP struct {
+	Interfaces *LACPInterfaces `xml:"interfaces"`
+}
+
+type LACPInterfaces struct {
+	Interface []LACPInterface `xml:"interface"`
+}
+
+type LACPInterface struct {
+	Name    *string             `xml:"name"`
+	State   *LACPInterfaceState `xml:"state"`
+	Members *LACPMembers        `xml:"members"`
+}
+
+type LACPInterfaceState struct {
+	Name           *string `xml:"name"`
+	SystemIDMac    *string `xml:"system-id-mac"`
+	SystemPriority *string `xml:"system-priority"`
+}
+
+type LACPMembers struct {
+	Member []LACPMember `xml:"member"`
+}
+
+type LACPMember struct {
+	Interface *string          `xml:"interface"`
+	State     *LACPMemberState `xml:"state"`
+}
+
+type LACPMemberState struct {
+	Interface  *string `xml:"interface"`
+	OperKey    *string `xml:"oper-key"`
+	PartnerID  *string `xml:"partner-id"`
+	PartnerKey *string `xml:"partner-key"`
+}
@@ -0,0 +1,93 @@
+package openconfig
+
+// LACPGetToMikrotikCmds parses the filter XML for the lacp subtree. Named interfaces
+// are monitored directly; otherwise the bonding table is listed so the caller can
+// discover which bonds to monitor.
+func LACPGetToMikrotikCmds(filterXML string) []string {
+	var f struct {
+		LACP *LACP `xml:"lacp"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	if f.LACP == nil {
+		return nil
+	}
+
+	var cmds []string
+	if f.LACP.Interfaces != nil {
+		for _, i := range f.LACP.Interfaces.Interface {
+			if i.Name != nil && *i.Name != "" {
+				cmds = append(cmds,
+					"/interface/bonding/monitor numbers="+*i.Name+" once=",
+					"/interface/bonding/monitor-slaves bond="+*i.Name+" once=")
+			}
+		}
+	}
+	if len(cmds) == 0 {
+		cmds = append(cmds, "/interface/bonding/print")
+	}
+	return cmds
+}
+
+// LACPStateFromMikrotik builds lacp/interfaces/interface state for a bond from the
+// /interface/bonding/monitor reply and the per-port /interface/bonding/monitor-slaves replies.
+// Fields RouterOS does not report (e.g. on non-802.3ad bonds) are left unset.
+func LACPStateFromMikrotik(bond string, monitor map[string]string, slaves []map[string]string) *LACPInterface {
+	iface := &LACPInterface{
+		Name:  &bond,
+		State: &LACPInterfaceState{Name: &bond},
+	}
+	iface.State.SystemIDMac = optional(monitor, "lacp-system-id")
+	iface.State.SystemPriority = optional(monitor, "lacp-system-priority")
+
+	for _, s := range slaves {
+		port := optional(s, "port")
+		if port == nil {
+			continue
+		}
+		if iface.Members == nil {
+			iface.Members = &LACPMembers{}
+		}
+		iface.Members.Member = append(iface.Members.Member, LACPMember{
+			Interface: port,
+			State: &LACPMemberState{
+				Interface:  port,
+				OperKey:    optional(s, "key"),
+				PartnerID:  optional(s, "partner-sys-id"),
+				PartnerKey: optional(s, "partner-key"),
+			},
+		})
+	}
+	return iface
+}
+
+// LACPGetFromMikrotik builds lacp/interfaces from the replies to LACPGetToMikrotikCmds. Named bonds
+// get their monitor state; otherwise every bond of the bonding table is listed by name.
+func LACPGetFromMikrotik(filterXML string, replies Replies) *LACP {
+	var f struct {
+		LACP *LACP `xml:"lacp"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	if f.LACP == nil {
+		return nil
+	}
+	out := &LACP{Interfaces: &LACPInterfaces{}}
+	if f.LACP.Interfaces != nil {
+		for _, i := range f.LACP.Interfaces.Interface {
+			if i.Name == nil || *i.Name == "" {
+				continue
+			}
+			monitor := replies.row("/interface/bonding/monitor numbers=" + *i.Name + " once=")
+			slaves := replies["/interface/bonding/monitor-slaves bond="+*i.Name+" once="]
+			if monitor == nil && slaves == nil {
+				continue
+			}
+			out.Interfaces.Interface = append(out.Interfaces.Interface, *LACPStateFromMikrotik(*i.Name, monitor, slaves))
+		}
+	}
+	for _, row := range replies["/interface/bonding/print"] {
+		if row["name"] != "" {
+			out.Interfaces.Interface = append(out.Interfaces.Interface, *LACPStateFromMikrotik(row["name"], nil, nil))
+		}
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLACPGetToMikrotikCmds_Named(t *testing.T) {
+	cmds := LACPGetToMikrotikCmds(`<lacp><interfaces><interface><name>bond1</name></interface></interfaces></lacp>`)
+	expected := []string{
+		"/interface/bonding/monitor numbers=bond1 once=",
+		"/interface/bonding/monitor-slaves bond=bond1 once=",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestLACPGetToMikrotikCmds_All(t *testing.T) {
+	cmds := LACPGetToMikrotikCmds(`<lacp/>`)
+	expected := []string{"/interface/bonding/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestLACPStateFromMikrotik(t *testing.T) {
+	monitor := map[string]string{"mode": "802.3ad", "lacp-system-id": "48:8F:5A:00:00:01", "lacp-system-priority": "65535"}
+	slaves := []map[string]string{
+		{"port": "ether1", "key": "17", "partner-sys-id": "00:11:22:33:44:55", "partner-key": "9"},
+		{"key": "17"},
+	}
+	iface := LACPStateFromMikrotik("bond1", monitor, slaves)
+	if *iface.State.SystemIDMac != "48:8F:5A:00:00:01" || *iface.State.SystemPriority != "65535" {
+		t.Errorf("unexpected interface state: %+v", iface.State)
+	}
+	if iface.Members == nil || len(iface.Members.Member) != 1 {
+		t.Fatalf("expected 1 member, got %+v", iface.Members)
+	}
+	m := iface.Members.Member[0].State
+	if *m.Interface != "ether1" || *m.OperKey != "17" || *m.PartnerID != "00:11:22:33:44:55" || *m.PartnerKey != "9" {
+		t.Errorf("unexpected member state: %+v", m)
+	}
+}
+
+func TestLACPGetFromMikrotik(t *testing.T) {
+	replies := Replies{
+		"/interface/bonding/monitor numbers=bond1 once=":     {{"lacp-system-id": "00:11:22:33:44:55"}},
+		"/interface/bonding/monitor-slaves bond=bond1 once=": {{"port": "ether1", "key": "9"}},
+	}
+	lacp := LACPGetFromMikrotik(`<lacp><interfaces><interface><name>bond1</name></interface></interfaces></lacp>`, replies)
+	if len(lacp.Interfaces.Interface) != 1 {
+		t.Fatalf("expected 1 interface, got %d", len(lacp.Interfaces.Interface))
+	}
+	i := lacp.Interfaces.Interface[0]
+	if *i.State.SystemIDMac != "00:11:22:33:44:55" || *i.Members.Member[0].State.OperKey != "9" {
+		t.Errorf("unexpected lacp state %+v", i)
+	}
+}
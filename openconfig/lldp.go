@@ -0,0 +1,64 @@
+package openconfig
+
+// LLDP represents the openconfig-lldp top-level container
+type LLDP struct {
+	Config     *LLDPConfig     `xml:"config"`
+	Interfaces *LLDPInterfaces `xml:"interfaces"`
+}
+
+type LLDPConfig struct {
+	Enabled *bool `xml:"enabled"`
+}
+
+type LLDPInterfaces struct {
+	Interface []LLDPInterface `xml:"interface"`
+}
+
+type LLDPInterface struct {
+	Name      *string              `xml:"name"`
+	Config    *LLDPInterfaceConfig `xml:"config"`
+	Neighbors *LLDPNeighbors       `xml:"neighbors"`
+}
+
+type LLDPInterfaceConfig struct {
+	Name    *string `xml:"name"`
+	Enabled *bool   `xml:"enabled"`
+}
+
+type LLDPNeighbors struct {
+	Neighbor []LLDPNeighbor `xml:"neighbor"`
+}
+
+type LLDPNeighbor struct {
+	ID    *string            `xml:"id"`
+	State *LLDPNeighborState `xml:"state"`
+}
+
+type LLDPNeighborState struct {
+	ID                *string `xml:"id"`
+	ChassisID         *string `xml:"chassis-id"`
+	ChassisIDType     *string `xml:"chassis-id-type"`
+	PortID            *string `xml:"port-id"`
+	PortIDType        *string `xml:"port-id-type"`
+	SystemName        *string `xml:"system-name"`
+	SystemDescription *string `xml:"system-description"`
+	ManagementAddress *string `xml:"management-address"`
+}
+
+// name returns the interface key, falling back to config/name
+func (i *LLDPInterface) name() string {
+	if i.Name != nil {
+		return *i.Name
+	}
+	if i.Config != nil && i.Config.Name != nil {
+		return *i.Config.Name
+	}
+	return ""
+}
+
+// lldpInterfaceList is the interface list neighbor discovery runs on. It includes every interface
+// except the members of lldpDisabledList, matching the OpenConfig default of LLDP enabled per interface.
+const (
+	lldpInterfaceList = "lldp"
+	lldpDisabledList  = "lldp-disabled"
+)
@@ -0,0 +1,70 @@
+package openconfig
+
+import "strings"
+
+// LLDPGetToMikrotikCmds parses the filter XML and dispatches to the LLDP handler for get operations.
+func LLDPGetToMikrotikCmds(filterXML string) []string {
+	var f struct {
+		LLDP *LLDP `xml:"lldp"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	return LLDPToMikrotikCmds("get", f.LLDP, nil)
+}
+
+// LLDPInterfacesFromMikrotik groups /ip/neighbor/print entries by the local interface they were
+// heard on and maps them onto lldp/interfaces/interface/neighbors/neighbor state: mac-address
+// becomes chassis-id (and the neighbor id), interface-name becomes port-id, identity becomes
+// system-name and address becomes management-address. Neighbors found only by MNDP or CDP are
+// skipped, as discovered-by does not list lldp for them.
+func LLDPInterfacesFromMikrotik(rows []map[string]string) *LLDPInterfaces {
+	ifs := &LLDPInterfaces{}
+	index := map[string]int{}
+	for _, row := range rows {
+		// RouterOS 7 reports the port and the bridge it belongs to, e.g. "ether2,bridge"
+		local, _, _ := strings.Cut(row["interface"], ",")
+		if local == "" || row["mac-address"] == "" || !strings.Contains(row["discovered-by"], "lldp") {
+			continue
+		}
+		n, ok := index[local]
+		if !ok {
+			name := local
+			n = len(ifs.Interface)
+			index[local] = n
+			ifs.Interface = append(ifs.Interface, LLDPInterface{Name: &name, Neighbors: &LLDPNeighbors{}})
+		}
+		id := row["mac-address"]
+		state := &LLDPNeighborState{
+			ID:                &id,
+			ChassisID:         &id,
+			PortID:            optional(row, "interface-name"),
+			SystemName:        optional(row, "identity"),
+			SystemDescription: optional(row, "system-description"),
+			ManagementAddress: optional(row, "address"),
+		}
+		macType := "MAC_ADDRESS"
+		state.ChassisIDType = &macType
+		if state.PortID != nil {
+			ifType := "INTERFACE_NAME"
+			state.PortIDType = &ifType
+		}
+		if state.ManagementAddress == nil {
+			state.ManagementAddress = optional(row, "address4")
+		}
+		neighbors := ifs.Interface[n].Neighbors
+		neighbors.Neighbor = append(neighbors.Neighbor, LLDPNeighbor{ID: &id, State: state})
+	}
+	return ifs
+}
+
+// LLDPGetFromMikrotik builds lldp/interfaces neighbor state from the /ip/neighbor rows
+func LLDPGetFromMikrotik(filterXML string, replies Replies) *LLDP {
+	var f struct {
+		LLDP *LLDP `xml:"lldp"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	rows := replies["/ip/neighbor/print"]
+	if f.LLDP == nil || rows == nil {
+		return nil
+	}
+	return &LLDP{Interfaces: LLDPInterfacesFromMikrotik(rows)}
+}
@@ -0,0 +1,84 @@
+package openconfig
+
+import "fmt"
+
+// LLDPToMikrotikCmds maps openconfig-lldp onto /ip/neighbor/discovery-settings. Discovery runs on the
+// "lldp" interface list, which includes all interfaces and excludes the "lldp-disabled" list;
+// interfaces/interface/config/enabled=false adds the interface to "lldp-disabled" and enabled=true
+// removes it again. config/enabled points discovery at the "lldp" list, or at none when disabled;
+// per-interface config without config/enabled also points discovery at the "lldp" list, since
+// the interface settings only take effect through it.
+func LLDPToMikrotikCmds(op string, lldp *LLDP, state *DeviceState) []string {
+	if lldp == nil {
+		return nil
+	}
+	switch op {
+	case "get":
+		return []string{"/ip/neighbor/discovery-settings/print", "/ip/neighbor/print", "/interface/list/member/print"}
+	case "set":
+		return lldpSetCmds(lldp, state)
+	}
+	return nil
+}
+
+func lldpSetCmds(lldp *LLDP, state *DeviceState) []string {
+	var cmds []string
+	disabling := lldp.Config != nil && lldp.Config.Enabled != nil && !*lldp.Config.Enabled
+	if lldp.Interfaces != nil || !disabling {
+		// The lists are created first: discovery-settings and list members must reference existing lists
+		cmds = append(cmds, lldpListCmds(state, lldpDisabledList, nil)...)
+		cmds = append(cmds, lldpListCmds(state, lldpInterfaceList, []attr{{"include", "all"}, {"exclude", lldpDisabledList}})...)
+	}
+
+	if lldp.Interfaces != nil {
+		for _, i := range lldp.Interfaces.Interface {
+			name := i.name()
+			if name == "" || i.Config == nil || i.Config.Enabled == nil {
+				continue
+			}
+			member := lldpDisabledMember(state, name)
+			switch {
+			case !*i.Config.Enabled && member == nil:
+				cmds = append(cmds, fmt.Sprintf("/interface/list/member/add list=%s interface=%s", lldpDisabledList, name))
+			case *i.Config.Enabled && member != nil:
+				cmds = append(cmds, "/interface/list/member/remove .id="+member[".id"])
+			case *i.Config.Enabled && state == nil:
+				cmds = append(cmds, fmt.Sprintf("/interface/list/member/remove [find list=%s interface=%s]", lldpDisabledList, name))
+			}
+		}
+	}
+
+	enabling := lldp.Config != nil && lldp.Config.Enabled != nil && *lldp.Config.Enabled
+	if !disabling && lldp.Interfaces != nil && len(lldp.Interfaces.Interface) > 0 {
+		enabling = true
+	}
+	if disabling || enabling {
+		if disabling {
+			cmds = append(cmds, "/ip/neighbor/discovery-settings/set discover-interface-list=none")
+		} else if state.majorVersion() >= 7 {
+			cmds = append(cmds, "/ip/neighbor/discovery-settings/set discover-interface-list="+lldpInterfaceList+" protocol=lldp")
+		} else {
+			// RouterOS 6 always runs MNDP, CDP and LLDP together
+			cmds = append(cmds, "/ip/neighbor/discovery-settings/set discover-interface-list="+lldpInterfaceList)
+		}
+	}
+	return cmds
+}
+
+// lldpListCmds adds or updates an interface list, leaving a list that already matches alone
+func lldpListCmds(state *DeviceState, name string, attrs []attr) []string {
+	if row := state.find("/interface/list", "name", name); row != nil && rowMatches(row, attrs, attrKeys(attrs)) {
+		return nil
+	}
+	return []string{upsertCmd(state, "/interface/list", "name", name, renderAttrs(attrs))}
+}
+
+// lldpDisabledMember returns the "lldp-disabled" list membership of an interface, or nil if there is none
+func lldpDisabledMember(state *DeviceState, iface string) map[string]string {
+	for _, row := range state.table("/interface/list/member") {
+		if row["list"] == lldpDisabledList && row["interface"] == iface {
+			return row
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLLDP_MikroTikCmd_Set_NoState(t *testing.T) {
+	enabled, off, on, eth1, eth2 := true, false, true, "ether1", "ether2"
+	lldp := &LLDP{Config: &LLDPConfig{Enabled: &enabled}, Interfaces: &LLDPInterfaces{Interface: []LLDPInterface{
+		{Name: &eth1, Config: &LLDPInterfaceConfig{Enabled: &off}},
+		{Name: &eth2, Config: &LLDPInterfaceConfig{Enabled: &on}},
+	}}}
+	cmds := LLDPToMikrotikCmds("set", lldp, nil)
+	expected := []string{
+		"/interface/list/add name=lldp-disabled",
+		"/interface/list/add name=lldp include=all exclude=lldp-disabled",
+		"/interface/list/member/add list=lldp-disabled interface=ether1",
+		"/interface/list/member/remove [find list=lldp-disabled interface=ether2]",
+		"/ip/neighbor/discovery-settings/set discover-interface-list=lldp protocol=lldp",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestLLDP_MikroTikCmd_Set_Reconcile(t *testing.T) {
+	enabled, off, on, eth1, eth2 := true, false, true, "ether1", "ether2"
+	lldp := &LLDP{Config: &LLDPConfig{Enabled: &enabled}, Interfaces: &LLDPInterfaces{Interface: []LLDPInterface{
+		{Name: &eth1, Config: &LLDPInterfaceConfig{Enabled: &off}},
+		{Name: &eth2, Config: &LLDPInterfaceConfig{Enabled: &on}},
+	}}}
+	state := &DeviceState{Version: "6.49.10 (long-term)", Tables: map[string][]map[string]string{
+		"/interface/list": {
+			{".id": "*1", "name": "lldp-disabled"},
+			{".id": "*2", "name": "lldp", "include": "all", "exclude": "lldp-disabled"},
+		},
+		"/interface/list/member": {
+			{".id": "*A", "list": "lldp-disabled", "interface": "ether1"},
+			{".id": "*B", "list": "lldp-disabled", "interface": "ether2"},
+		},
+	}}
+	cmds := LLDPToMikrotikCmds("set", lldp, state)
+	expected := []string{
+		"/interface/list/member/remove .id=*B",
+		"/ip/neighbor/discovery-settings/set discover-interface-list=lldp",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestLLDP_MikroTikCmd_Set_InterfaceOnly(t *testing.T) {
+	off, eth1 := false, "ether1"
+	lldp := &LLDP{Interfaces: &LLDPInterfaces{Interface: []LLDPInterface{{Name: &eth1, Config: &LLDPInterfaceConfig{Enabled: &off}}}}}
+	state := &DeviceState{Version: "7.12", Tables: map[string][]map[string]string{
+		"/interface/list": {
+			{".id": "*1", "name": "lldp-disabled"},
+			{".id": "*2", "name": "lldp", "include": "all"},
+		},
+	}}
+	cmds := LLDPToMikrotikCmds("set", lldp, state)
+	expected := []string{
+		"/interface/list/set .id=*2 include=all exclude=lldp-disabled",
+		"/interface/list/member/add list=lldp-disabled interface=ether1",
+		"/ip/neighbor/discovery-settings/set discover-interface-list=lldp protocol=lldp",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestLLDP_MikroTikCmd_Set_Disable(t *testing.T) {
+	disabled := false
+	cmds := LLDPToMikrotikCmds("set", &LLDP{Config: &LLDPConfig{Enabled: &disabled}}, nil)
+	expected := []string{"/ip/neighbor/discovery-settings/set discover-interface-list=none"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestLLDPInterfacesFromMikrotik(t *testing.T) {
+	rows := []map[string]string{
+		{"interface": "ether2,bridge", "mac-address": "48:8F:5A:00:00:01", "identity": "core-sw", "interface-name": "sfp1", "address": "10.0.0.2", "discovered-by": "cdp,lldp,mndp"},
+		{"interface": "ether2", "mac-address": "48:8F:5A:00:00:02", "identity": "ap-1", "address4": "10.0.0.3", "discovered-by": "lldp"},
+		{"interface": "ether3", "identity": "no-mac", "discovered-by": "lldp"},
+		{"interface": "ether4", "mac-address": "48:8F:5A:00:00:04", "identity": "mndp-only", "discovered-by": "mndp"},
+	}
+	ifs := LLDPInterfacesFromMikrotik(rows)
+	if len(ifs.Interface) != 1 || ifs.Interface[0].name() != "ether2" {
+		t.Fatalf("expected neighbors on ether2 only, got %+v", ifs.Interface)
+	}
+	neighbors := ifs.Interface[0].Neighbors.Neighbor
+	if len(neighbors) != 2 {
+		t.Fatalf("expected 2 neighbors, got %d", len(neighbors))
+	}
+	first := neighbors[0].State
+	if *first.ChassisID != "48:8F:5A:00:00:01" || *first.PortID != "sfp1" || *first.SystemName != "core-sw" || *first.ManagementAddress != "10.0.0.2" {
+		t.Errorf("unexpected neighbor state %+v", first)
+	}
+	if second := neighbors[1].State; second.PortID != nil || *second.ManagementAddress != "10.0.0.3" {
+		t.Errorf("unexpected neighbor state %+v", second)
+	}
+}
+
+func TestLLDP_MikroTikCmd_Get(t *testing.T) {
+	cmds := LLDPGetToMikrotikCmds("<lldp/>")
+	expected := []string{"/ip/neighbor/discovery-settings/print", "/ip/neighbor/print", "/interface/list/member/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
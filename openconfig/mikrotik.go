@@ -0,0 +1,101 @@
+package openconfig
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// unmarshalFilter decodes a NETCONF subtree filter into v. The filter body may
+// hold several top-level containers (e.g. <system/><vlans/>), so it is wrapped
+// in a synthetic <filter> element and v is expected to name the roots it cares about.
+func unmarshalFilter(filterXML string, v interface{}) error {
+	return xml.Unmarshal([]byte("<filter>"+filterXML+"</filter>"), v)
+}
+
+// Replies holds the rows the device returned for the print commands of a get, keyed by the
+// command exactly as the module's GetToMikrotikCmds produced it
+type Replies map[string][]map[string]string
+
+// row returns the first row printed for cmd, or nil if the command was not run or returned nothing
+func (r Replies) row(cmd string) map[string]string {
+	if len(r[cmd]) == 0 {
+		return nil
+	}
+	return r[cmd][0]
+}
+
+// quote renders a MikroTik argument value in double quotes, escaping any
+// embedded quotes or backslashes so parseCommand keeps it as a single word.
+// Newlines, carriage returns and tabs become \n, \r and \t so every command stays on one line.
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// identity strips the module prefix from an OpenConfig identityref value,
+// e.g. "oc-vlan-types:TRUNK" -> "TRUNK"
+func identity(s string) string {
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
+// optional returns a pointer to a RouterOS reply field, or nil if it is absent or empty
+func optional(reply map[string]string, key string) *string {
+	if v, ok := reply[key]; ok && v != "" {
+		return &v
+	}
+	return nil
+}
+
+// isDeleteOperation reports whether a NETCONF edit-config operation attribute removes the entry
+func isDeleteOperation(operation *string) bool {
+	return operation != nil && (*operation == "delete" || *operation == "remove")
+}
+
+// yesNo maps a boolean onto the yes/no values MikroTik uses for flags
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// attr is a single property=value argument of a MikroTik add/set command
+type attr struct {
+	key, value string
+}
+
+// renderAttrs renders attrs as " key=value" arguments
+func renderAttrs(attrs []attr) string {
+	out := ""
+	for _, a := range attrs {
+		out += " " + a.key + "=" + a.value
+	}
+	return out
+}
+
+// attrKeys returns the keys of attrs, for rowMatches when only the given values are managed
+func attrKeys(attrs []attr) []string {
+	keys := make([]string, len(attrs))
+	for i, a := range attrs {
+		keys[i] = a.key
+	}
+	return keys
+}
+
+// rowMatches reports whether an existing row already has exactly the given values
+// for every managed key; keys missing from attrs must be unset on the row
+func rowMatches(row map[string]string, attrs []attr, managed []string) bool {
+	want := map[string]string{}
+	for _, a := range attrs {
+		want[a.key] = a.value
+	}
+	for _, k := range managed {
+		if row[k] != want[k] {
+			return false
+		}
+	}
+	return true
+}
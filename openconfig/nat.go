@@ -0,0 +1,80 @@
+package openconfig
+
+// NAT is a vendor augmentation (there is no agreed OpenConfig NAT model) describing
+// source NAT masquerading per egress interface and destination NAT port forwards.
+//
+//	<nat>
+//	  <masquerades><masquerade><interface>ether1</interface>...</masquerade></masquerades>
+//	  <port-forwards><port-forward><name>web</name><config>...</config></port-forward></port-forwards>
+//	</nat>
+type NAT struct {
+	Masquerades  *NATMasquerades  `xml:"masquerades"`
+	PortForwards *NATPortForwards `xml:"port-forwards"`
+}
+
+type NATMasquerades struct {
+	Masquerade []NATMasquerade `xml:"masquerade"`
+}
+
+type NATMasquerade struct {
+	Operation *string              `xml:"operation,attr"`
+	Interface *string              `xml:"interface"` // egress interface, the list key
+	Config    *NATMasqueradeConfig `xml:"config"`
+}
+
+type NATMasqueradeConfig struct {
+	Interface     *string `xml:"interface"`
+	SourceAddress *string `xml:"source-address"` // optional prefix to restrict masquerading to
+}
+
+type NATPortForwards struct {
+	PortForward []NATPortForward `xml:"port-forward"`
+}
+
+type NATPortForward struct {
+	Operation *string               `xml:"operation,attr"`
+	Name      *string               `xml:"name"`
+	Config    *NATPortForwardConfig `xml:"config"`
+}
+
+type NATPortForwardConfig struct {
+	Name             *string `xml:"name"`
+	IngressInterface *string `xml:"ingress-interface"`
+	Protocol         *string `xml:"protocol"` // tcp or udp
+	ExternalAddress  *string `xml:"external-address"`
+	ExternalPort     *string `xml:"external-port"` // port or "1000..2000" range
+	InternalAddress  *string `xml:"internal-address"`
+	InternalPort     *string `xml:"internal-port"`
+}
+
+// iface returns the masquerade key, falling back to config/interface
+func (m *NATMasquerade) iface() string {
+	if m.Interface != nil {
+		return *m.Interface
+	}
+	if m.Config != nil && m.Config.Interface != nil {
+		return *m.Config.Interface
+	}
+	return ""
+}
+
+// name returns the port-forward key, falling back to config/name
+func (p *NATPortForward) name() string {
+	if p.Name != nil {
+		return *p.Name
+	}
+	if p.Config != nil && p.Config.Name != nil {
+		return *p.Config.Name
+	}
+	return ""
+}
+
+// natMasqueradeComment and natPortForwardComment tag the /ip/firewall/nat rules implementing
+// each list entry, so they can be found again and reconciled
+func natMasqueradeComment(iface string) string {
+	return "nat:masquerade:" + iface
+}
+
+func natPortForwardComment(name string) string {
+	return "nat:port-forward:" + name
+}
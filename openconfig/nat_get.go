@@ -0,0 +1,58 @@
+package openconfig
+
+import "strings"
+
+// NATGetToMikrotikCmds parses the filter XML and dispatches to the NAT handler for get operations.
+func NATGetToMikrotikCmds(filterXML string) []string {
+	var f struct {
+		NAT *NAT `xml:"nat"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	return NATToMikrotikCmds("get", f.NAT, nil)
+}
+
+// NATFromMikrotik reverse-maps the tagged /ip/firewall/nat rules back into the NAT model.
+// Rules without a nat: comment tag were not created by the translator and are skipped.
+func NATFromMikrotik(rows []map[string]string) *NAT {
+	nat := &NAT{Masquerades: &NATMasquerades{}, PortForwards: &NATPortForwards{}}
+	for _, row := range rows {
+		if iface, ok := strings.CutPrefix(row["comment"], "nat:masquerade:"); ok {
+			nat.Masquerades.Masquerade = append(nat.Masquerades.Masquerade, NATMasquerade{
+				Interface: &iface,
+				Config:    &NATMasqueradeConfig{Interface: &iface, SourceAddress: optional(row, "src-address")},
+			})
+		}
+		if name, ok := strings.CutPrefix(row["comment"], "nat:port-forward:"); ok {
+			cfg := &NATPortForwardConfig{
+				Name:             &name,
+				IngressInterface: optional(row, "in-interface"),
+				Protocol:         optional(row, "protocol"),
+				ExternalAddress:  optional(row, "dst-address"),
+				InternalAddress:  optional(row, "to-addresses"),
+			}
+			if p := optional(row, "dst-port"); p != nil {
+				port := strings.Replace(*p, "-", "..", 1)
+				cfg.ExternalPort = &port
+			}
+			if p := optional(row, "to-ports"); p != nil {
+				port := strings.Replace(*p, "-", "..", 1)
+				cfg.InternalPort = &port
+			}
+			nat.PortForwards.PortForward = append(nat.PortForwards.PortForward, NATPortForward{Name: &name, Config: cfg})
+		}
+	}
+	return nat
+}
+
+// NATGetFromMikrotik builds the NAT vendor augmentation from the /ip/firewall/nat rows
+func NATGetFromMikrotik(filterXML string, replies Replies) *NAT {
+	var f struct {
+		NAT *NAT `xml:"nat"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	rows := replies["/ip/firewall/nat/print"]
+	if f.NAT == nil || rows == nil {
+		return nil
+	}
+	return NATFromMikrotik(rows)
+}
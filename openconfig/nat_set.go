@@ -0,0 +1,124 @@
+package openconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// natManagedKeys are the /ip/firewall/nat properties a NAT entry manages
+var natManagedKeys = []string{
+	"chain", "action", "out-interface", "src-address", "in-interface",
+	"protocol", "dst-address", "dst-port", "to-addresses", "to-ports",
+}
+
+// NATToMikrotikCmds maps the NAT vendor augmentation onto /ip/firewall/nat: each masquerade
+// becomes a srcnat masquerade rule on its egress interface and each port-forward a dstnat
+// dst-nat rule. Rules are tagged with a "nat:masquerade:<interface>" or "nat:port-forward:<name>"
+// comment. NAT rules are ordered, so with device state unchanged rules are kept and changed ones
+// are set in place; an edit merges, and a rule is only removed when its entry carries a delete
+// operation. Without state the tagged rule is removed before it is added, so re-running an edit
+// does not leave duplicates.
+func NATToMikrotikCmds(op string, nat *NAT, state *DeviceState) []string {
+	if nat == nil {
+		return nil
+	}
+	switch op {
+	case "get":
+		return []string{"/ip/firewall/nat/print"}
+	case "set":
+		var cmds []string
+		if nat.Masquerades != nil {
+			for _, m := range nat.Masquerades.Masquerade {
+				if m.iface() == "" {
+					continue
+				}
+				attrs := []attr{{"chain", "srcnat"}, {"action", "masquerade"}, {"out-interface", m.iface()}}
+				if m.Config != nil && m.Config.SourceAddress != nil && *m.Config.SourceAddress != "" {
+					attrs = append(attrs, attr{"src-address", *m.Config.SourceAddress})
+				}
+				cmds = append(cmds, natRuleCmds(state, natMasqueradeComment(m.iface()), attrs, m.Operation)...)
+			}
+		}
+		if nat.PortForwards != nil {
+			for _, p := range nat.PortForwards.PortForward {
+				if p.name() == "" {
+					continue
+				}
+				if isDeleteOperation(p.Operation) {
+					cmds = append(cmds, natRuleCmds(state, natPortForwardComment(p.name()), nil, p.Operation)...)
+					continue
+				}
+				if p.Config == nil {
+					continue
+				}
+				attrs, err := natPortForwardAttrs(p.Config)
+				if err != nil {
+					cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: nat port-forward %s: %v", p.name(), err))
+					continue
+				}
+				cmds = append(cmds, natRuleCmds(state, natPortForwardComment(p.name()), attrs, p.Operation)...)
+			}
+		}
+		return cmds
+	}
+	return nil
+}
+
+// natRuleCmds adds, updates or (for a delete operation) removes the NAT rule tagged tag. A changed
+// rule is set in place so it keeps its position, unsetting managed properties it no longer has.
+func natRuleCmds(state *DeviceState, tag string, attrs []attr, operation *string) []string {
+	const menu = "/ip/firewall/nat"
+	if isDeleteOperation(operation) {
+		return removeCmds(state, menu, "comment", tag)
+	}
+	row := state.find(menu, "comment", tag)
+	if row == nil {
+		// Without state the rule may already exist, so any copy of it is removed first
+		var cmds []string
+		if state == nil {
+			cmds = removeCmds(state, menu, "comment", tag)
+		}
+		return append(cmds, menu+"/add"+renderAttrs(attrs)+" comment="+quote(tag))
+	}
+	if rowMatches(row, attrs, natManagedKeys) {
+		return nil
+	}
+	cmds := []string{menu + "/set .id=" + row[".id"] + renderAttrs(attrs)}
+	set := map[string]bool{}
+	for _, a := range attrs {
+		set[a.key] = true
+	}
+	for _, k := range natManagedKeys {
+		if !set[k] && row[k] != "" {
+			cmds = append(cmds, menu+"/unset numbers="+row[".id"]+" value-name="+k)
+		}
+	}
+	return cmds
+}
+
+func natPortForwardAttrs(cfg *NATPortForwardConfig) ([]attr, error) {
+	if cfg.InternalAddress == nil || *cfg.InternalAddress == "" {
+		return nil, fmt.Errorf("missing internal-address")
+	}
+	attrs := []attr{{"chain", "dstnat"}, {"action", "dst-nat"}}
+	if cfg.IngressInterface != nil && *cfg.IngressInterface != "" {
+		attrs = append(attrs, attr{"in-interface", *cfg.IngressInterface})
+	}
+	if cfg.Protocol != nil && *cfg.Protocol != "" {
+		attrs = append(attrs, attr{"protocol", strings.ToLower(*cfg.Protocol)})
+	}
+	if cfg.ExternalAddress != nil && *cfg.ExternalAddress != "" {
+		attrs = append(attrs, attr{"dst-address", *cfg.ExternalAddress})
+	}
+	if cfg.ExternalPort != nil && *cfg.ExternalPort != "" {
+		if cfg.Protocol == nil {
+			return nil, fmt.Errorf("external-port requires a protocol")
+		}
+		attrs = append(attrs, attr{"dst-port", strings.Replace(*cfg.ExternalPort, "..", "-", 1)})
+	}
+	attrs = append(attrs, attr{"to-addresses", *cfg.InternalAddress})
+	if cfg.InternalPort != nil && *cfg.InternalPort != "" {
+		attrs = append(attrs, attr{"to-ports", strings.Replace(*cfg.InternalPort, "..", "-", 1)})
+	}
+	return attrs, nil
+}
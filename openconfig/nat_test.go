@@ -0,0 +1,98 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNAT_MikroTikCmd_Set_NoState(t *testing.T) {
+	wan, lan := "ether1", "192.168.88.0/24"
+	name, proto, ext, addr, port := "web", "TCP", "8080", "192.168.88.10", "80"
+	nat := &NAT{
+		Masquerades: &NATMasquerades{Masquerade: []NATMasquerade{
+			{Interface: &wan, Config: &NATMasqueradeConfig{SourceAddress: &lan}},
+		}},
+		PortForwards: &NATPortForwards{PortForward: []NATPortForward{
+			{Name: &name, Config: &NATPortForwardConfig{IngressInterface: &wan, Protocol: &proto, ExternalPort: &ext, InternalAddress: &addr, InternalPort: &port}},
+		}},
+	}
+	cmds := NATToMikrotikCmds("set", nat, nil)
+	expected := []string{
+		`/ip/firewall/nat/remove [find comment="nat:masquerade:ether1"]`,
+		`/ip/firewall/nat/add chain=srcnat action=masquerade out-interface=ether1 src-address=192.168.88.0/24 comment="nat:masquerade:ether1"`,
+		`/ip/firewall/nat/remove [find comment="nat:port-forward:web"]`,
+		`/ip/firewall/nat/add chain=dstnat action=dst-nat in-interface=ether1 protocol=tcp dst-port=8080 to-addresses=192.168.88.10 to-ports=80 comment="nat:port-forward:web"`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestNAT_MikroTikCmd_Set_Reconcile(t *testing.T) {
+	wan, lte, lan, del := "ether1", "lte1", "192.168.88.0/24", "delete"
+	name, proto, ext, addr, port := "web", "TCP", "8080", "192.168.88.10", "80"
+	nat := &NAT{
+		Masquerades: &NATMasquerades{Masquerade: []NATMasquerade{
+			{Interface: &wan, Config: &NATMasqueradeConfig{SourceAddress: &lan}},
+			{Interface: &lte, Operation: &del},
+		}},
+		PortForwards: &NATPortForwards{PortForward: []NATPortForward{
+			{Name: &name, Config: &NATPortForwardConfig{IngressInterface: &wan, Protocol: &proto, ExternalPort: &ext, InternalAddress: &addr, InternalPort: &port}},
+		}},
+	}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/ip/firewall/nat": {
+			{".id": "*1", "chain": "srcnat", "action": "masquerade", "out-interface": "ether1", "src-address": "192.168.88.0/24", "comment": "nat:masquerade:ether1"},
+			{".id": "*2", "chain": "srcnat", "action": "masquerade", "out-interface": "lte1", "comment": "nat:masquerade:lte1"},
+			{".id": "*3", "chain": "dstnat", "action": "dst-nat", "in-interface": "ether1", "protocol": "tcp", "dst-port": "8080", "dst-address": "203.0.113.1", "to-addresses": "192.168.88.20", "to-ports": "80", "comment": "nat:port-forward:web"},
+			{".id": "*4", "chain": "dstnat", "action": "dst-nat", "to-addresses": "10.0.0.1", "comment": "defconf"},
+			{".id": "*5", "chain": "dstnat", "action": "dst-nat", "to-addresses": "192.168.88.30", "comment": "nat:port-forward:mail"},
+		},
+	}}
+	cmds := NATToMikrotikCmds("set", nat, state)
+	expected := []string{
+		"/ip/firewall/nat/remove .id=*2",
+		"/ip/firewall/nat/set .id=*3 chain=dstnat action=dst-nat in-interface=ether1 protocol=tcp dst-port=8080 to-addresses=192.168.88.10 to-ports=80",
+		"/ip/firewall/nat/unset numbers=*3 value-name=dst-address",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestNAT_MikroTikCmd_Set_MissingInternalAddress(t *testing.T) {
+	name := "broken"
+	nat := &NAT{PortForwards: &NATPortForwards{PortForward: []NATPortForward{{Name: &name, Config: &NATPortForwardConfig{}}}}}
+	cmds := NATToMikrotikCmds("set", nat, nil)
+	expected := []string{"UNSUPPORTED: nat port-forward broken: missing internal-address"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestNATFromMikrotik(t *testing.T) {
+	rows := []map[string]string{
+		{"chain": "srcnat", "action": "masquerade", "out-interface": "ether1", "comment": "nat:masquerade:ether1"},
+		{"chain": "dstnat", "action": "dst-nat", "protocol": "udp", "dst-port": "5000-5010", "to-addresses": "10.0.0.5", "comment": "nat:port-forward:rtp"},
+		{"chain": "srcnat", "action": "masquerade", "comment": "defconf: masquerade"},
+	}
+	nat := NATFromMikrotik(rows)
+	if len(nat.Masquerades.Masquerade) != 1 || nat.Masquerades.Masquerade[0].iface() != "ether1" {
+		t.Errorf("expected one masquerade on ether1, got %+v", nat.Masquerades.Masquerade)
+	}
+	if len(nat.PortForwards.PortForward) != 1 {
+		t.Fatalf("expected one port-forward, got %d", len(nat.PortForwards.PortForward))
+	}
+	cfg := nat.PortForwards.PortForward[0].Config
+	if *cfg.Name != "rtp" || *cfg.Protocol != "udp" || *cfg.ExternalPort != "5000..5010" || *cfg.InternalAddress != "10.0.0.5" || cfg.InternalPort != nil {
+		t.Errorf("unexpected port-forward config %+v", cfg)
+	}
+}
+
+func TestNAT_MikroTikCmd_Get(t *testing.T) {
+	cmds := NATGetToMikrotikCmds("<nat/>")
+	expected := []string{"/ip/firewall/nat/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
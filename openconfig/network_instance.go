@@ -0,0 +1,114 @@
+package openconfig
+
+// NetworkInstances represents the openconfig-network-instance top-level container
+type NetworkInstances struct {
+	NetworkInstance []NetworkInstance `xml:"network-instance"`
+}
+
+type NetworkInstance struct {
+	Name       *string                    `xml:"name"`
+	Config     *NetworkInstanceConfig     `xml:"config"`
+	Interfaces *NetworkInstanceInterfaces `xml:"interfaces"`
+	Protocols  *NetworkInstanceProtocols  `xml:"protocols"`
+}
+
+type NetworkInstanceConfig struct {
+	Name               *string `xml:"name"`
+	Type               *string `xml:"type"` // DEFAULT_INSTANCE or L3VRF
+	RouteDistinguisher *string `xml:"route-distinguisher"`
+}
+
+type NetworkInstanceInterfaces struct {
+	Interface []NetworkInstanceInterface `xml:"interface"`
+}
+
+type NetworkInstanceInterface struct {
+	ID     *string                         `xml:"id"`
+	Config *NetworkInstanceInterfaceConfig `xml:"config"`
+}
+
+type NetworkInstanceInterfaceConfig struct {
+	ID        *string `xml:"id"`
+	Interface *string `xml:"interface"`
+}
+
+type NetworkInstanceProtocols struct {
+	Protocol []Protocol `xml:"protocol"`
+}
+
+type Protocol struct {
+	Identifier   *string       `xml:"identifier"` // e.g. STATIC, BGP, OSPF
+	Name         *string       `xml:"name"`
+	StaticRoutes *StaticRoutes `xml:"static-routes"`
+	BGP          *BGP          `xml:"bgp"`
+	OSPFv2       *OSPFv2       `xml:"ospfv2"`
+}
+
+// name returns the network-instance key, falling back to config/name
+func (ni *NetworkInstance) name() string {
+	if ni.Name != nil {
+		return *ni.Name
+	}
+	if ni.Config != nil && ni.Config.Name != nil {
+		return *ni.Config.Name
+	}
+	return ""
+}
+
+// instanceType returns the network-instance type identity. Instances other than
+// DEFAULT without an explicit type are treated as L3VRF.
+func (ni *NetworkInstance) instanceType() string {
+	if ni.Config != nil && ni.Config.Type != nil {
+		return identity(*ni.Config.Type)
+	}
+	if ni.name() == "" || ni.name() == "DEFAULT" {
+		return "DEFAULT_INSTANCE"
+	}
+	return "L3VRF"
+}
+
+// isDefault reports whether this is the DEFAULT instance, i.e. the RouterOS main routing table
+func (ni *NetworkInstance) isDefault() bool {
+	return ni.instanceType() == "DEFAULT_INSTANCE"
+}
+
+// routingTable returns the RouterOS routing table (routing-mark on RouterOS 6) holding this instance's routes
+func (ni *NetworkInstance) routingTable() string {
+	if ni.isDefault() {
+		return "main"
+	}
+	return ni.name()
+}
+
+// interfaceNames returns the interfaces bound to this instance
+func (ni *NetworkInstance) interfaceNames() []string {
+	if ni.Interfaces == nil {
+		return nil
+	}
+	var names []string
+	for _, i := range ni.Interfaces.Interface {
+		switch {
+		case i.Config != nil && i.Config.Interface != nil:
+			names = append(names, *i.Config.Interface)
+		case i.ID != nil:
+			names = append(names, *i.ID)
+		}
+	}
+	return names
+}
+
+// identifier returns the protocol identity, inferring it from the subtree present if omitted
+func (p *Protocol) identifier() string {
+	if p.Identifier != nil {
+		return identity(*p.Identifier)
+	}
+	switch {
+	case p.StaticRoutes != nil:
+		return "STATIC"
+	case p.BGP != nil:
+		return "BGP"
+	case p.OSPFv2 != nil:
+		return "OSPF"
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package openconfig
+
+// NetworkInstancesGetToMikrotikCmds parses the filter XML and dispatches to the protocol handlers for get operations.
+func NetworkInstancesGetToMikrotikCmds(filterXML string) []string {
+	var f struct {
+		NetworkInstances *NetworkInstances `xml:"network-instances"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	return NetworkInstancesToMikrotikCmds("get", f.NetworkInstances, nil)
+}
+
+// NetworkInstancesGetFromMikrotik builds the protocols of each network-instance in a get filter from
+// the replies to NetworkInstancesGetToMikrotikCmds: static routes of the instance's routing table,
+// BGP neighbor state and OSPFv2 neighbor state
+func NetworkInstancesGetFromMikrotik(filterXML string, replies Replies) *NetworkInstances {
+	var f struct {
+		NetworkInstances *NetworkInstances `xml:"network-instances"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	if f.NetworkInstances == nil {
+		return nil
+	}
+	out := &NetworkInstances{}
+	for n := range f.NetworkInstances.NetworkInstance {
+		ni := &f.NetworkInstances.NetworkInstance[n]
+		name := ni.name()
+		if name == "" {
+			name = "DEFAULT"
+		}
+		instance := NetworkInstance{Name: &name, Protocols: &NetworkInstanceProtocols{}}
+		if ni.Protocols != nil {
+			for p := range ni.Protocols.Protocol {
+				proto := &ni.Protocols.Protocol[p]
+				id := proto.identifier()
+				read := Protocol{Identifier: &id, Name: proto.Name}
+				switch id {
+				case "STATIC":
+					rows := append(append([]map[string]string{}, replies["/ip/route/print"]...), replies["/ipv6/route/print"]...)
+					read.StaticRoutes = StaticRoutesFromMikrotik(rows, ni.routingTable())
+				case "BGP":
+					read.BGP = &BGP{Neighbors: BGPNeighborsStateFromMikrotik(replies["/routing/bgp/session/print"])}
+				case "OSPF":
+					read.OSPFv2 = &OSPFv2{Areas: OSPFv2AreasStateFromMikrotik(replies["/routing/ospf/area/print"], replies["/routing/ospf/neighbor/print"])}
+				default:
+					continue
+				}
+				instance.Protocols.Protocol = append(instance.Protocols.Protocol, read)
+			}
+		}
+		out.NetworkInstance = append(out.NetworkInstance, instance)
+	}
+	return out
+}
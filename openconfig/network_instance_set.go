@@ -0,0 +1,41 @@
+package openconfig
+
+import "fmt"
+
+type ProtocolHandler func(op string, ni *NetworkInstance, p *Protocol, state *DeviceState) []string
+
+var protocolHandlers = map[string]ProtocolHandler{
+	"STATIC": handleStaticRoutes,
+	"BGP":    handleBGP,
+	"OSPF":   handleOSPFv2,
+}
+
+// NetworkInstancesToMikrotikCmds maps each network-instance onto its routing table (see
+// handleNetworkInstanceVRF) and dispatches its protocols to their handlers for get/set
+func NetworkInstancesToMikrotikCmds(op string, nis *NetworkInstances, state *DeviceState) []string {
+	if nis == nil {
+		return nil
+	}
+	var cmds []string
+	for n := range nis.NetworkInstance {
+		ni := &nis.NetworkInstance[n]
+		switch t := ni.instanceType(); t {
+		case "DEFAULT_INSTANCE":
+		case "L3VRF":
+			cmds = append(cmds, handleNetworkInstanceVRF(op, ni, state)...)
+		default:
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: network-instance %s type %s is not supported on MikroTik", ni.name(), t))
+			continue
+		}
+		if ni.Protocols == nil {
+			continue
+		}
+		for p := range ni.Protocols.Protocol {
+			proto := &ni.Protocols.Protocol[p]
+			if handler, ok := protocolHandlers[proto.identifier()]; ok {
+				cmds = append(cmds, handler(op, ni, proto, state)...)
+			}
+		}
+	}
+	return cmds
+}
@@ -0,0 +1,106 @@
+package openconfig
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// OSPFv2 represents protocols/protocol[OSPF]/ospfv2
+type OSPFv2 struct {
+	Global *OSPFv2Global `xml:"global"`
+	Areas  *OSPFv2Areas  `xml:"areas"`
+}
+
+type OSPFv2Global struct {
+	Config *OSPFv2GlobalConfig `xml:"config"`
+}
+
+type OSPFv2GlobalConfig struct {
+	RouterID *string `xml:"router-id"`
+}
+
+type OSPFv2Areas struct {
+	Area []OSPFv2Area `xml:"area"`
+}
+
+type OSPFv2Area struct {
+	Identifier *string           `xml:"identifier"`
+	Config     *OSPFv2AreaConfig `xml:"config"`
+	Interfaces *OSPFv2Interfaces `xml:"interfaces"`
+}
+
+type OSPFv2AreaConfig struct {
+	Identifier *string `xml:"identifier"`
+}
+
+type OSPFv2Interfaces struct {
+	Interface []OSPFv2Interface `xml:"interface"`
+}
+
+type OSPFv2Interface struct {
+	ID        *string                `xml:"id"`
+	Config    *OSPFv2InterfaceConfig `xml:"config"`
+	Timers    *OSPFv2Timers          `xml:"timers"`
+	Neighbors *OSPFv2Neighbors       `xml:"neighbors"`
+}
+
+type OSPFv2InterfaceConfig struct {
+	ID          *string `xml:"id"`
+	Metric      *uint16 `xml:"metric"`
+	NetworkType *string `xml:"network-type"` // e.g. POINT_TO_POINT_NETWORK
+	Passive     *bool   `xml:"passive"`
+}
+
+type OSPFv2Timers struct {
+	Config *OSPFv2TimersConfig `xml:"config"`
+}
+
+type OSPFv2TimersConfig struct {
+	DeadInterval           *uint32 `xml:"dead-interval"`
+	HelloInterval          *uint32 `xml:"hello-interval"`
+	RetransmissionInterval *uint16 `xml:"retransmission-interval"`
+}
+
+type OSPFv2Neighbors struct {
+	Neighbor []OSPFv2Neighbor `xml:"neighbor"`
+}
+
+type OSPFv2Neighbor struct {
+	RouterID *string              `xml:"router-id"`
+	State    *OSPFv2NeighborState `xml:"state"`
+}
+
+type OSPFv2NeighborState struct {
+	RouterID        *string `xml:"router-id"`
+	NeighborAddress *string `xml:"neighbor-address"`
+	Priority        *uint8  `xml:"priority"`
+	AdjacencyState  *string `xml:"adjacency-state"` // e.g. FULL
+	StateChanges    *uint32 `xml:"state-changes"`
+}
+
+// id returns the area key in dotted-quad form, as RouterOS expects for area-id.
+// OpenConfig also allows a plain 32-bit integer, e.g. "0" for the backbone.
+func (a *OSPFv2Area) id() string {
+	id := ""
+	switch {
+	case a.Identifier != nil:
+		id = *a.Identifier
+	case a.Config != nil && a.Config.Identifier != nil:
+		id = *a.Config.Identifier
+	}
+	if n, err := strconv.ParseUint(id, 10, 32); err == nil {
+		return fmt.Sprintf("%d.%d.%d.%d", n>>24, n>>16&0xff, n>>8&0xff, n&0xff)
+	}
+	return id
+}
+
+// id returns the interface key, falling back to config/id
+func (i *OSPFv2Interface) id() string {
+	if i.ID != nil {
+		return *i.ID
+	}
+	if i.Config != nil && i.Config.ID != nil {
+		return *i.Config.ID
+	}
+	return ""
+}
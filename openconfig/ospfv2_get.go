@@ -0,0 +1,75 @@
+package openconfig
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ospfAdjacencyStates maps RouterOS neighbor states onto OpenConfig adjacency-state identities
+var ospfAdjacencyStates = map[string]string{
+	"down":     "DOWN",
+	"attempt":  "ATTEMPT",
+	"init":     "INIT",
+	"2-way":    "TWO_WAY",
+	"exstart":  "EXSTART",
+	"exchange": "EXCHANGE",
+	"loading":  "LOADING",
+	"full":     "FULL",
+}
+
+// OSPFv2AreasStateFromMikrotik builds areas/area/interfaces/interface/neighbors from
+// /routing/ospf/neighbor rows, using /routing/ospf/area rows to translate the RouterOS
+// area names back into area identifiers.
+func OSPFv2AreasStateFromMikrotik(areaRows, neighborRows []map[string]string) *OSPFv2Areas {
+	areaIDs := map[string]string{}
+	for _, row := range areaRows {
+		areaIDs[row["name"]] = row["area-id"]
+	}
+
+	result := &OSPFv2Areas{}
+	areaIndex := map[string]int{}
+	ifaceIndex := map[string]int{}
+	for _, row := range neighborRows {
+		routerID := row["router-id"]
+		if routerID == "" {
+			continue
+		}
+		areaID := areaIDs[row["area"]]
+		if areaID == "" {
+			areaID = row["area"]
+		}
+		ai, ok := areaIndex[areaID]
+		if !ok {
+			id := areaID
+			result.Area = append(result.Area, OSPFv2Area{Identifier: &id, Interfaces: &OSPFv2Interfaces{}})
+			ai = len(result.Area) - 1
+			areaIndex[areaID] = ai
+		}
+		area := &result.Area[ai]
+
+		ifaceKey := areaID + "/" + row["interface"]
+		ii, ok := ifaceIndex[ifaceKey]
+		if !ok {
+			name := row["interface"]
+			area.Interfaces.Interface = append(area.Interfaces.Interface, OSPFv2Interface{ID: &name, Neighbors: &OSPFv2Neighbors{}})
+			ii = len(area.Interfaces.Interface) - 1
+			ifaceIndex[ifaceKey] = ii
+		}
+		iface := &area.Interfaces.Interface[ii]
+
+		state := &OSPFv2NeighborState{RouterID: &routerID, NeighborAddress: optional(row, "address")}
+		if s, ok := ospfAdjacencyStates[strings.ToLower(row["state"])]; ok {
+			state.AdjacencyState = &s
+		}
+		if p, err := strconv.ParseUint(row["priority"], 10, 8); err == nil {
+			priority := uint8(p)
+			state.Priority = &priority
+		}
+		if c, err := strconv.ParseUint(row["state-changes"], 10, 32); err == nil {
+			changes := uint32(c)
+			state.StateChanges = &changes
+		}
+		iface.Neighbors.Neighbor = append(iface.Neighbors.Neighbor, OSPFv2Neighbor{RouterID: &routerID, State: state})
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package openconfig
+
+import "fmt"
+
+// ospfNetworkTypes maps OpenConfig ospf-types network-type identities onto interface-template type
+var ospfNetworkTypes = map[string]string{
+	"POINT_TO_POINT_NETWORK": "ptp",
+	"BROADCAST_NETWORK":      "broadcast",
+	"NON_BROADCAST_NETWORK":  "nbma",
+}
+
+// ospfInstanceName returns the /routing/ospf/instance name used for a network-instance
+func ospfInstanceName(ni *NetworkInstance) string {
+	if ni.isDefault() {
+		return "default-v2"
+	}
+	return ni.name() + "-v2"
+}
+
+// handleOSPFv2 maps ospfv2 onto RouterOS 7: global/config onto a version 2 /routing/ospf/instance
+// per network-instance, each area onto a /routing/ospf/area named "<instance>-<area-id>", and each
+// area interface onto a /routing/ospf/interface-template with its cost, type, passive flag and timers.
+// Interface templates are tagged with an "ospf:<area>:<interface>" comment, so the same interface
+// in two areas gets a template per area and each is updated in place.
+func handleOSPFv2(op string, ni *NetworkInstance, p *Protocol, state *DeviceState) []string {
+	switch op {
+	case "get":
+		return []string{
+			"/routing/ospf/instance/print",
+			"/routing/ospf/area/print",
+			"/routing/ospf/interface-template/print",
+			"/routing/ospf/neighbor/print",
+		}
+	case "set":
+		if p.OSPFv2 == nil {
+			return nil
+		}
+		if state.majorVersion() < 7 {
+			return []string{"UNSUPPORTED: protocol OSPF is only supported on RouterOS 7 (/routing/ospf/instance)"}
+		}
+
+		instance := ospfInstanceName(ni)
+		args := " version=2"
+		if g := p.OSPFv2.Global; g != nil && g.Config != nil && g.Config.RouterID != nil && *g.Config.RouterID != "" {
+			args += " router-id=" + *g.Config.RouterID
+		}
+		if !ni.isDefault() {
+			args += " vrf=" + ni.name()
+		}
+		cmds := []string{upsertCmd(state, "/routing/ospf/instance", "name", instance, args)}
+
+		if p.OSPFv2.Areas == nil {
+			return cmds
+		}
+		for _, a := range p.OSPFv2.Areas.Area {
+			if a.id() == "" {
+				continue
+			}
+			area := instance + "-" + a.id()
+			cmds = append(cmds, upsertCmd(state, "/routing/ospf/area", "name", area,
+				fmt.Sprintf(" area-id=%s instance=%s", a.id(), instance)))
+			if a.Interfaces == nil {
+				continue
+			}
+			for _, i := range a.Interfaces.Interface {
+				if i.id() == "" {
+					continue
+				}
+				cmds = append(cmds, ospfInterfaceTemplateCmds(state, area, &i)...)
+			}
+		}
+		return cmds
+	}
+	return nil
+}
+
+// ospfInterfaceTemplateCmds adds or updates the interface-template tagged for the interface in
+// area, reporting a network-type RouterOS has no template type for
+func ospfInterfaceTemplateCmds(state *DeviceState, area string, i *OSPFv2Interface) []string {
+	const menu = "/routing/ospf/interface-template"
+	tag := "ospf:" + area + ":" + i.id()
+	args := " interfaces=" + i.id() + " area=" + area + ospfInterfaceArgs(i)
+	cmds := []string{menu + "/add comment=" + quote(tag) + args}
+	if row := state.find(menu, "comment", tag); row != nil {
+		cmds[0] = menu + "/set .id=" + row[".id"] + args
+	}
+	if cfg := i.Config; cfg != nil && cfg.NetworkType != nil {
+		if _, ok := ospfNetworkTypes[identity(*cfg.NetworkType)]; !ok {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: ospfv2 interface %s network-type %s is not supported on MikroTik", i.id(), *cfg.NetworkType))
+		}
+	}
+	return cmds
+}
+
+func ospfInterfaceArgs(i *OSPFv2Interface) string {
+	args := ""
+	if cfg := i.Config; cfg != nil {
+		if cfg.Metric != nil {
+			args += fmt.Sprintf(" cost=%d", *cfg.Metric)
+		}
+		if cfg.NetworkType != nil {
+			if t, ok := ospfNetworkTypes[identity(*cfg.NetworkType)]; ok {
+				args += " type=" + t
+			}
+		}
+		if cfg.Passive != nil {
+			args += " passive=" + yesNo(*cfg.Passive)
+		}
+	}
+	if i.Timers != nil && i.Timers.Config != nil {
+		t := i.Timers.Config
+		if t.HelloInterval != nil {
+			args += fmt.Sprintf(" hello-interval=%ds", *t.HelloInterval)
+		}
+		if t.DeadInterval != nil {
+			args += fmt.Sprintf(" dead-interval=%ds", *t.DeadInterval)
+		}
+		if t.RetransmissionInterval != nil {
+			args += fmt.Sprintf(" retransmit-interval=%ds", *t.RetransmissionInterval)
+		}
+	}
+	return args
+}
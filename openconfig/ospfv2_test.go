@@ -0,0 +1,142 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOSPFv2_MikroTikCmd_Set(t *testing.T) {
+	name, id, routerID := "DEFAULT", "OSPF", "192.0.2.1"
+	area0, area1 := "0", "0.0.0.1"
+	lan, wan := "bridge", "ether1"
+	var cost uint16 = 10
+	var hello, dead uint32 = 5, 20
+	p2p := "oc-ospf-types:POINT_TO_POINT_NETWORK"
+	passive := true
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name: &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{
+			Identifier: &id,
+			OSPFv2: &OSPFv2{
+				Global: &OSPFv2Global{Config: &OSPFv2GlobalConfig{RouterID: &routerID}},
+				Areas: &OSPFv2Areas{Area: []OSPFv2Area{
+					{Identifier: &area0, Interfaces: &OSPFv2Interfaces{Interface: []OSPFv2Interface{{
+						ID:     &wan,
+						Config: &OSPFv2InterfaceConfig{Metric: &cost, NetworkType: &p2p},
+						Timers: &OSPFv2Timers{Config: &OSPFv2TimersConfig{HelloInterval: &hello, DeadInterval: &dead}},
+					}}}},
+					{Identifier: &area1, Interfaces: &OSPFv2Interfaces{Interface: []OSPFv2Interface{{
+						ID:     &lan,
+						Config: &OSPFv2InterfaceConfig{Passive: &passive},
+					}}}},
+				}},
+			},
+		}}},
+	}}}
+	state := &DeviceState{Version: "7.12", Tables: map[string][]map[string]string{
+		"/routing/ospf/instance": {{".id": "*1", "name": "default-v2"}},
+	}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, state)
+	expected := []string{
+		"/routing/ospf/instance/set .id=*1 version=2 router-id=192.0.2.1",
+		"/routing/ospf/area/add name=default-v2-0.0.0.0 area-id=0.0.0.0 instance=default-v2",
+		`/routing/ospf/interface-template/add comment="ospf:default-v2-0.0.0.0:ether1" interfaces=ether1 area=default-v2-0.0.0.0 cost=10 type=ptp hello-interval=5s dead-interval=20s`,
+		"/routing/ospf/area/add name=default-v2-0.0.0.1 area-id=0.0.0.1 instance=default-v2",
+		`/routing/ospf/interface-template/add comment="ospf:default-v2-0.0.0.1:bridge" interfaces=bridge area=default-v2-0.0.0.1 passive=yes`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestOSPFv2_MikroTikCmd_Set_InterfaceInTwoAreas(t *testing.T) {
+	name, id := "DEFAULT", "OSPF"
+	area0, area1, wan := "0.0.0.0", "0.0.0.1", "ether1"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name: &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{
+			Identifier: &id,
+			OSPFv2: &OSPFv2{Areas: &OSPFv2Areas{Area: []OSPFv2Area{
+				{Identifier: &area0, Interfaces: &OSPFv2Interfaces{Interface: []OSPFv2Interface{{ID: &wan}}}},
+				{Identifier: &area1, Interfaces: &OSPFv2Interfaces{Interface: []OSPFv2Interface{{ID: &wan}}}},
+			}}},
+		}}},
+	}}}
+	state := &DeviceState{Version: "7.12", Tables: map[string][]map[string]string{
+		"/routing/ospf/instance": {{".id": "*1", "name": "default-v2"}},
+		"/routing/ospf/area": {
+			{".id": "*2", "name": "default-v2-0.0.0.0"},
+			{".id": "*3", "name": "default-v2-0.0.0.1"},
+		},
+		"/routing/ospf/interface-template": {
+			{".id": "*4", "interfaces": "ether1", "area": "default-v2-0.0.0.0", "comment": "ospf:default-v2-0.0.0.0:ether1"},
+		},
+	}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, state)
+	expected := []string{
+		"/routing/ospf/instance/set .id=*1 version=2",
+		"/routing/ospf/area/set .id=*2 area-id=0.0.0.0 instance=default-v2",
+		"/routing/ospf/interface-template/set .id=*4 interfaces=ether1 area=default-v2-0.0.0.0",
+		"/routing/ospf/area/set .id=*3 area-id=0.0.0.1 instance=default-v2",
+		`/routing/ospf/interface-template/add comment="ospf:default-v2-0.0.0.1:ether1" interfaces=ether1 area=default-v2-0.0.0.1`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestOSPFv2_MikroTikCmd_Set_UnknownNetworkType(t *testing.T) {
+	name, id, area0, wan := "DEFAULT", "OSPF", "0", "ether1"
+	ptmp := "oc-ospf-types:POINT_TO_MULTIPOINT_NETWORK"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name: &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{
+			Identifier: &id,
+			OSPFv2: &OSPFv2{Areas: &OSPFv2Areas{Area: []OSPFv2Area{
+				{Identifier: &area0, Interfaces: &OSPFv2Interfaces{Interface: []OSPFv2Interface{{
+					ID:     &wan,
+					Config: &OSPFv2InterfaceConfig{NetworkType: &ptmp},
+				}}}},
+			}}},
+		}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, &DeviceState{Version: "7.12"})
+	expected := []string{
+		"/routing/ospf/instance/add name=default-v2 version=2",
+		"/routing/ospf/area/add name=default-v2-0.0.0.0 area-id=0.0.0.0 instance=default-v2",
+		`/routing/ospf/interface-template/add comment="ospf:default-v2-0.0.0.0:ether1" interfaces=ether1 area=default-v2-0.0.0.0`,
+		"UNSUPPORTED: ospfv2 interface ether1 network-type oc-ospf-types:POINT_TO_MULTIPOINT_NETWORK is not supported on MikroTik",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestOSPFv2AreaID_Integer(t *testing.T) {
+	id := "16777217"
+	a := &OSPFv2Area{Identifier: &id}
+	if a.id() != "1.0.0.1" {
+		t.Errorf("expected 1.0.0.1, got %s", a.id())
+	}
+}
+
+func TestOSPFv2AreasStateFromMikrotik(t *testing.T) {
+	areas := []map[string]string{{"name": "default-v2-0.0.0.0", "area-id": "0.0.0.0"}}
+	neighbors := []map[string]string{
+		{"instance": "default-v2", "area": "default-v2-0.0.0.0", "interface": "ether1", "address": "10.0.0.2",
+			"router-id": "192.0.2.2", "priority": "1", "state": "Full", "state-changes": "6"},
+	}
+	result := OSPFv2AreasStateFromMikrotik(areas, neighbors)
+	if len(result.Area) != 1 || *result.Area[0].Identifier != "0.0.0.0" {
+		t.Fatalf("unexpected areas %+v", result.Area)
+	}
+	iface := result.Area[0].Interfaces.Interface[0]
+	if *iface.ID != "ether1" || len(iface.Neighbors.Neighbor) != 1 {
+		t.Fatalf("unexpected interface %+v", iface)
+	}
+	st := iface.Neighbors.Neighbor[0].State
+	if *st.RouterID != "192.0.2.2" || *st.NeighborAddress != "10.0.0.2" || *st.AdjacencyState != "FULL" ||
+		*st.Priority != 1 || *st.StateChanges != 6 {
+		t.Errorf("unexpected neighbor state %+v", st)
+	}
+}
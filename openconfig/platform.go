@@ -0,0 +1,82 @@
+package openconfig
+
+// Components represents the openconfig-platform top-level components container. Only operational
+// state is modelled; RouterOS hardware inventory is read-only.
+type Components struct {
+	Component []Component `xml:"component"`
+}
+
+type Component struct {
+	Name       *string              `xml:"name"`
+	State      *ComponentState      `xml:"state"`
+	CPU        *ComponentCPU        `xml:"cpu"`
+	Properties *ComponentProperties `xml:"properties"`
+}
+
+type ComponentState struct {
+	Name            *string               `xml:"name"`
+	Type            *string               `xml:"type"`
+	Description     *string               `xml:"description"`
+	MfgName         *string               `xml:"mfg-name"`
+	PartNo          *string               `xml:"part-no"`
+	SerialNo        *string               `xml:"serial-no"`
+	HardwareVersion *string               `xml:"hardware-version"`
+	FirmwareVersion *string               `xml:"firmware-version"`
+	SoftwareVersion *string               `xml:"software-version"`
+	Parent          *string               `xml:"parent"`
+	Temperature     *ComponentTemperature `xml:"temperature"`
+}
+
+type ComponentTemperature struct {
+	Instant *float64 `xml:"instant"`
+}
+
+type ComponentCPU struct {
+	Utilization *ComponentCPUUtilization `xml:"utilization"`
+}
+
+type ComponentCPUUtilization struct {
+	State *ComponentCPUUtilizationState `xml:"state"`
+}
+
+type ComponentCPUUtilizationState struct {
+	Instant *uint8 `xml:"instant"`
+}
+
+type ComponentProperties struct {
+	Property []ComponentProperty `xml:"property"`
+}
+
+type ComponentProperty struct {
+	Name  *string                 `xml:"name"`
+	State *ComponentPropertyState `xml:"state"`
+}
+
+type ComponentPropertyState struct {
+	Name  *string `xml:"name"`
+	Value *string `xml:"value"`
+}
+
+// RouterOS has no component names, so the translator uses fixed names for the components it builds;
+// /system/health sensors are named after their RouterOS sensor name.
+const (
+	platformChassis = "chassis"
+	platformCPU     = "cpu"
+	platformStorage = "storage"
+	platformOS      = "routeros"
+)
+
+// property adds a name/value property to the component, skipping empty values
+func (c *Component) property(name, value string) {
+	if value == "" {
+		return
+	}
+	if c.Properties == nil {
+		c.Properties = &ComponentProperties{}
+	}
+	n, v := name, value
+	c.Properties.Property = append(c.Properties.Property, ComponentProperty{
+		Name:  &n,
+		State: &ComponentPropertyState{Name: &n, Value: &v},
+	})
+}
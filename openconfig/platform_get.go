@@ -0,0 +1,182 @@
+package openconfig
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PlatformGetToMikrotikCmds parses the filter XML for the components subtree. Named components only
+// print the tables they are built from; otherwise the whole inventory is read.
+func PlatformGetToMikrotikCmds(filterXML string) []string {
+	var f struct {
+		Components *Components `xml:"components"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	if f.Components == nil {
+		return nil
+	}
+
+	all := len(f.Components.Component) == 0
+	routerboard, resource, health := all, all, all
+	for _, c := range f.Components.Component {
+		name := ""
+		if c.Name != nil {
+			name = *c.Name
+		}
+		switch name {
+		case "":
+			routerboard, resource, health = true, true, true
+		case platformChassis, platformOS:
+			routerboard, resource = true, true
+		case platformCPU, platformStorage:
+			resource = true
+		default:
+			health = true
+		}
+	}
+	var cmds []string
+	if routerboard {
+		cmds = append(cmds, "/system/routerboard/print")
+	}
+	if resource {
+		cmds = append(cmds, "/system/resource/print")
+	}
+	if health {
+		cmds = append(cmds, "/system/health/print")
+	}
+	return cmds
+}
+
+// PlatformComponentsFromMikrotik builds components/component state from the /system/routerboard and
+// /system/resource replies and the /system/health rows:
+//   - "chassis" (CHASSIS): routerboard model → part-no, serial-number → serial-no, revision →
+//     hardware-version, current-firmware → firmware-version, and the resource board-name as description
+//   - "cpu" (CPU): resource cpu → description, cpu-load → cpu/utilization/state/instant, cpu-count and
+//     cpu-frequency (MHz) as properties
+//   - "storage" (STORAGE): total-hdd-space and free-hdd-space (bytes) as properties
+//   - "routeros" (OPERATING_SYSTEM): version → software-version, with the routerboard upgrade-firmware
+//     and whether it differs from current-firmware as properties
+//   - one SENSOR per /system/health temperature (temperature/instant, °C) or voltage (a "voltage" property)
+//
+// Components whose source table is missing (e.g. /system/routerboard on CHR) are left out.
+func PlatformComponentsFromMikrotik(routerboard, resource map[string]string, health []map[string]string) *Components {
+	comps := &Components{}
+	add := func(name, typ string) *Component {
+		n, t := name, typ
+		c := Component{Name: &n, State: &ComponentState{Name: &n, Type: &t}}
+		if name != platformChassis {
+			parent := platformChassis
+			c.State.Parent = &parent
+		}
+		comps.Component = append(comps.Component, c)
+		return &comps.Component[len(comps.Component)-1]
+	}
+
+	if len(routerboard) > 0 || len(resource) > 0 {
+		chassis := add(platformChassis, "CHASSIS")
+		mfg := "MikroTik"
+		chassis.State.MfgName = &mfg
+		chassis.State.Description = optional(resource, "board-name")
+		chassis.State.PartNo = optional(routerboard, "model")
+		chassis.State.SerialNo = optional(routerboard, "serial-number")
+		chassis.State.HardwareVersion = optional(routerboard, "revision")
+		chassis.State.FirmwareVersion = optional(routerboard, "current-firmware")
+	}
+
+	if len(resource) > 0 {
+		cpu := add(platformCPU, "CPU")
+		cpu.State.Description = optional(resource, "cpu")
+		if load, err := strconv.ParseUint(resource["cpu-load"], 10, 8); err == nil {
+			instant := uint8(load)
+			cpu.CPU = &ComponentCPU{Utilization: &ComponentCPUUtilization{State: &ComponentCPUUtilizationState{Instant: &instant}}}
+		}
+		cpu.property("cpu-count", resource["cpu-count"])
+		cpu.property("cpu-frequency", resource["cpu-frequency"])
+
+		if resource["total-hdd-space"] != "" {
+			storage := add(platformStorage, "STORAGE")
+			storage.property("total-hdd-space", resource["total-hdd-space"])
+			storage.property("free-hdd-space", resource["free-hdd-space"])
+		}
+
+		system := add(platformOS, "OPERATING_SYSTEM")
+		desc := "RouterOS"
+		system.State.Description = &desc
+		system.State.SoftwareVersion = optional(resource, "version")
+		if upgrade := routerboard["upgrade-firmware"]; upgrade != "" {
+			system.property("upgrade-firmware", upgrade)
+			system.property("firmware-upgrade-pending", strconv.FormatBool(upgrade != routerboard["current-firmware"]))
+		}
+	}
+
+	for _, sensor := range healthSensors(health) {
+		name, value := sensor[0], sensor[1]
+		switch {
+		case strings.Contains(name, "temperature"):
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			c := add(name, "SENSOR")
+			c.State.Temperature = &ComponentTemperature{Instant: &v}
+		case strings.Contains(name, "voltage"):
+			c := add(name, "SENSOR")
+			c.property("voltage", value)
+		}
+	}
+	return comps
+}
+
+// PlatformGetFromMikrotik builds the components a get filter selects from the replies to
+// PlatformGetToMikrotikCmds; named components are picked out of the full inventory
+func PlatformGetFromMikrotik(filterXML string, replies Replies) *Components {
+	var f struct {
+		Components *Components `xml:"components"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	if f.Components == nil {
+		return nil
+	}
+	comps := PlatformComponentsFromMikrotik(replies.row("/system/routerboard/print"), replies.row("/system/resource/print"), replies["/system/health/print"])
+	names := map[string]bool{}
+	for _, c := range f.Components.Component {
+		if c.Name == nil || *c.Name == "" {
+			return comps
+		}
+		names[*c.Name] = true
+	}
+	if len(names) == 0 {
+		return comps
+	}
+	selected := &Components{}
+	for _, c := range comps.Component {
+		if names[*c.Name] {
+			selected.Component = append(selected.Component, c)
+		}
+	}
+	return selected
+}
+
+// healthSensors flattens /system/health output into name/value pairs. RouterOS 7 prints one row
+// per sensor (name, value, type); RouterOS 6 prints a single row with one property per sensor.
+func healthSensors(rows []map[string]string) [][2]string {
+	var sensors [][2]string
+	for _, row := range rows {
+		if name, ok := row["name"]; ok {
+			sensors = append(sensors, [2]string{name, row["value"]})
+			continue
+		}
+		keys := make([]string, 0, len(row))
+		for k := range row {
+			if !strings.HasPrefix(k, ".") {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sensors = append(sensors, [2]string{k, row[k]})
+		}
+	}
+	return sensors
+}
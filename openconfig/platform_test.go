@@ -0,0 +1,94 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlatformGetToMikrotikCmds_All(t *testing.T) {
+	cmds := PlatformGetToMikrotikCmds(`<components/>`)
+	expected := []string{"/system/routerboard/print", "/system/resource/print", "/system/health/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestPlatformGetToMikrotikCmds_Named(t *testing.T) {
+	cmds := PlatformGetToMikrotikCmds(`<components><component><name>cpu</name></component><component><name>cpu-temperature</name></component></components>`)
+	expected := []string{"/system/resource/print", "/system/health/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestPlatformComponentsFromMikrotik(t *testing.T) {
+	routerboard := map[string]string{"model": "RB4011iGS+", "serial-number": "D4C00A1B2C3D", "revision": "r2",
+		"current-firmware": "7.11", "upgrade-firmware": "7.12"}
+	resource := map[string]string{"board-name": "RB4011iGS+", "version": "7.12 (stable)", "cpu": "ARM",
+		"cpu-count": "4", "cpu-frequency": "1400", "cpu-load": "7", "total-hdd-space": "536870912", "free-hdd-space": "469762048"}
+	health := []map[string]string{
+		{"name": "voltage", "value": "24.1", "type": "V"},
+		{"name": "cpu-temperature", "value": "47", "type": "C"},
+		{"name": "fan1-speed", "value": "3200", "type": "RPM"},
+	}
+	comps := PlatformComponentsFromMikrotik(routerboard, resource, health)
+
+	var names []string
+	byName := map[string]Component{}
+	for _, c := range comps.Component {
+		names = append(names, *c.Name)
+		byName[*c.Name] = c
+	}
+	expected := []string{"chassis", "cpu", "storage", "routeros", "voltage", "cpu-temperature"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+
+	chassis := byName["chassis"].State
+	if *chassis.Type != "CHASSIS" || *chassis.PartNo != "RB4011iGS+" || *chassis.SerialNo != "D4C00A1B2C3D" ||
+		*chassis.HardwareVersion != "r2" || *chassis.FirmwareVersion != "7.11" || chassis.Parent != nil {
+		t.Errorf("unexpected chassis state: %+v", chassis)
+	}
+	if load := byName["cpu"].CPU.Utilization.State.Instant; *load != 7 {
+		t.Errorf("expected cpu load 7, got %d", *load)
+	}
+	if v := *byName["routeros"].State.SoftwareVersion; v != "7.12 (stable)" {
+		t.Errorf("expected software-version 7.12 (stable), got %s", v)
+	}
+	props := map[string]string{}
+	for _, p := range byName["routeros"].Properties.Property {
+		props[*p.Name] = *p.State.Value
+	}
+	if props["upgrade-firmware"] != "7.12" || props["firmware-upgrade-pending"] != "true" {
+		t.Errorf("unexpected operating system properties: %v", props)
+	}
+	if temp := byName["cpu-temperature"].State.Temperature; temp == nil || *temp.Instant != 47 {
+		t.Errorf("expected cpu-temperature 47, got %+v", temp)
+	}
+	if v := byName["voltage"].Properties.Property[0]; *v.Name != "voltage" || *v.State.Value != "24.1" {
+		t.Errorf("unexpected voltage property: %s=%s", *v.Name, *v.State.Value)
+	}
+}
+
+func TestPlatformComponentsFromMikrotik_RouterOS6Health(t *testing.T) {
+	comps := PlatformComponentsFromMikrotik(nil, nil, []map[string]string{{"temperature": "38", "voltage": "12.2"}})
+	if len(comps.Component) != 2 || *comps.Component[0].Name != "temperature" || *comps.Component[1].Name != "voltage" {
+		t.Fatalf("unexpected components: %+v", comps.Component)
+	}
+	if *comps.Component[0].State.Temperature.Instant != 38 {
+		t.Errorf("expected temperature 38, got %v", *comps.Component[0].State.Temperature.Instant)
+	}
+}
+
+func TestPlatformGetFromMikrotik_Named(t *testing.T) {
+	replies := Replies{
+		"/system/resource/print": {{"cpu": "ARM", "cpu-load": "7", "version": "7.15.3 (stable)"}},
+	}
+	comps := PlatformGetFromMikrotik(`<components><component><name>cpu</name></component></components>`, replies)
+	if len(comps.Component) != 1 || *comps.Component[0].Name != "cpu" {
+		t.Fatalf("expected only the cpu component, got %+v", comps.Component)
+	}
+	if *comps.Component[0].CPU.Utilization.State.Instant != 7 {
+		t.Errorf("unexpected cpu utilization %+v", comps.Component[0].CPU)
+	}
+}
@@ -0,0 +1,183 @@
+package openconfig
+
+// QoS represents the openconfig-qos top-level container
+type QoS struct {
+	Classifiers       *QoSClassifiers       `xml:"classifiers"`
+	ForwardingGroups  *QoSForwardingGroups  `xml:"forwarding-groups"`
+	SchedulerPolicies *QoSSchedulerPolicies `xml:"scheduler-policies"`
+	Interfaces        *QoSInterfaces        `xml:"interfaces"`
+}
+
+type QoSClassifiers struct {
+	Classifier []QoSClassifier `xml:"classifier"`
+}
+
+type QoSClassifier struct {
+	Name  *string             `xml:"name"`
+	Terms *QoSClassifierTerms `xml:"terms"`
+}
+
+type QoSClassifierTerms struct {
+	Term []QoSClassifierTerm `xml:"term"`
+}
+
+type QoSClassifierTerm struct {
+	ID         *string        `xml:"id"`
+	Conditions *QoSConditions `xml:"conditions"`
+	Actions    *QoSActions    `xml:"actions"`
+}
+
+type QoSConditions struct {
+	IPv4 *QoSIPCondition `xml:"ipv4"`
+	IPv6 *QoSIPCondition `xml:"ipv6"`
+}
+
+type QoSIPCondition struct {
+	Config *QoSIPConditionConfig `xml:"config"`
+}
+
+type QoSIPConditionConfig struct {
+	DSCP *uint8 `xml:"dscp"`
+}
+
+type QoSActions struct {
+	Config *QoSActionsConfig `xml:"config"`
+}
+
+type QoSActionsConfig struct {
+	TargetGroup *string `xml:"target-group"` // forwarding-group name
+}
+
+type QoSForwardingGroups struct {
+	ForwardingGroup []QoSForwardingGroup `xml:"forwarding-group"`
+}
+
+type QoSForwardingGroup struct {
+	Name   *string                   `xml:"name"`
+	Config *QoSForwardingGroupConfig `xml:"config"`
+}
+
+type QoSForwardingGroupConfig struct {
+	Name        *string `xml:"name"`
+	OutputQueue *string `xml:"output-queue"`
+}
+
+type QoSSchedulerPolicies struct {
+	SchedulerPolicy []QoSSchedulerPolicy `xml:"scheduler-policy"`
+}
+
+type QoSSchedulerPolicy struct {
+	Name       *string        `xml:"name"`
+	Schedulers *QoSSchedulers `xml:"schedulers"`
+}
+
+type QoSSchedulers struct {
+	Scheduler []QoSScheduler `xml:"scheduler"`
+}
+
+type QoSScheduler struct {
+	Sequence        *uint32             `xml:"sequence"`
+	Config          *QoSSchedulerConfig `xml:"config"`
+	Inputs          *QoSSchedulerInputs `xml:"inputs"`
+	OneRateTwoColor *QoSOneRateTwoColor `xml:"one-rate-two-color"`
+}
+
+type QoSSchedulerConfig struct {
+	Sequence *uint32 `xml:"sequence"`
+	Type     *string `xml:"type"` // ONE_RATE_TWO_COLOR
+}
+
+type QoSSchedulerInputs struct {
+	Input []QoSSchedulerInput `xml:"input"`
+}
+
+type QoSSchedulerInput struct {
+	ID     *string                  `xml:"id"`
+	Config *QoSSchedulerInputConfig `xml:"config"`
+}
+
+type QoSSchedulerInputConfig struct {
+	ID    *string `xml:"id"`
+	Queue *string `xml:"queue"`
+}
+
+type QoSOneRateTwoColor struct {
+	Config *QoSOneRateTwoColorConfig `xml:"config"`
+}
+
+type QoSOneRateTwoColorConfig struct {
+	CIR *uint64 `xml:"cir"` // committed information rate, bits per second
+	BC  *uint32 `xml:"bc"`  // committed burst size, bytes
+}
+
+type QoSInterfaces struct {
+	Interface []QoSInterface `xml:"interface"`
+}
+
+type QoSInterface struct {
+	InterfaceID *string             `xml:"interface-id"`
+	Output      *QoSInterfaceOutput `xml:"output"`
+}
+
+type QoSInterfaceOutput struct {
+	SchedulerPolicy *QoSInterfaceSchedulerPolicy `xml:"scheduler-policy"`
+	Queues          *QoSInterfaceQueues          `xml:"queues"`
+}
+
+type QoSInterfaceSchedulerPolicy struct {
+	Config *QoSInterfaceSchedulerPolicyConfig `xml:"config"`
+}
+
+type QoSInterfaceSchedulerPolicyConfig struct {
+	Name *string `xml:"name"`
+}
+
+type QoSInterfaceQueues struct {
+	Queue []QoSInterfaceQueue `xml:"queue"`
+}
+
+type QoSInterfaceQueue struct {
+	Name  *string                 `xml:"name"`
+	State *QoSInterfaceQueueState `xml:"state"`
+}
+
+type QoSInterfaceQueueState struct {
+	Name           *string `xml:"name"`
+	TransmitPkts   *uint64 `xml:"transmit-pkts"`
+	TransmitOctets *uint64 `xml:"transmit-octets"`
+	DroppedPkts    *uint64 `xml:"dropped-pkts"`
+}
+
+// sequence returns the scheduler key, falling back to config/sequence
+func (s *QoSScheduler) sequence() uint32 {
+	if s.Sequence != nil {
+		return *s.Sequence
+	}
+	if s.Config != nil && s.Config.Sequence != nil {
+		return *s.Config.Sequence
+	}
+	return 0
+}
+
+// queue returns the queue a scheduler input feeds from, or "" if it names none
+func (i *QoSSchedulerInput) queue() string {
+	if i.Config != nil && i.Config.Queue != nil {
+		return *i.Config.Queue
+	}
+	return ""
+}
+
+// qosSimpleQueueName names the /queue/simple entry shaping all output of an interface
+func qosSimpleQueueName(iface string) string {
+	return "qos-" + iface
+}
+
+// qosTreeQueueName names the /queue/tree entry shaping one OpenConfig queue on an interface
+func qosTreeQueueName(iface, queue string) string {
+	return "qos-" + iface + "-" + queue
+}
+
+// qosTermComment tags the /ip/firewall/mangle rule implementing a classifier term
+func qosTermComment(classifier, term string) string {
+	return "qos:" + classifier + ":" + term
+}
@@ -0,0 +1,80 @@
+package openconfig
+
+import (
+	"strconv"
+	"strings"
+)
+
+// QoSGetToMikrotikCmds parses the filter XML and dispatches to the QoS handler for get operations.
+func QoSGetToMikrotikCmds(filterXML string) []string {
+	var f struct {
+		QoS *QoS `xml:"qos"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	return QoSToMikrotikCmds("get", f.QoS, nil)
+}
+
+// QoSInterfaceQueuesStateFromMikrotik maps the queues shaping an interface, as printed with stats,
+// onto qos/interfaces/interface/output/queues state. Each /queue/tree entry reports its OpenConfig
+// queue; the interface's /queue/simple entry is reported under its own name using the download
+// direction, which is traffic leaving through the target interface.
+func QoSInterfaceQueuesStateFromMikrotik(iface string, simpleRows, treeRows []map[string]string) *QoSInterfaceQueues {
+	queues := &QoSInterfaceQueues{}
+	add := func(name, packets, bytes, dropped string) {
+		state := &QoSInterfaceQueueState{Name: &name}
+		if v, err := strconv.ParseUint(packets, 10, 64); err == nil {
+			state.TransmitPkts = &v
+		}
+		if v, err := strconv.ParseUint(bytes, 10, 64); err == nil {
+			state.TransmitOctets = &v
+		}
+		if v, err := strconv.ParseUint(dropped, 10, 64); err == nil {
+			state.DroppedPkts = &v
+		}
+		queues.Queue = append(queues.Queue, QoSInterfaceQueue{Name: &name, State: state})
+	}
+	for _, row := range treeRows {
+		if row["parent"] != iface {
+			continue
+		}
+		if q, ok := strings.CutPrefix(row["name"], qosTreeQueueName(iface, "")); ok && q != "" {
+			add(q, row["packets"], row["bytes"], row["dropped"])
+		}
+	}
+	for _, row := range simpleRows {
+		if row["name"] == qosSimpleQueueName(iface) {
+			add(row["name"], download(row["packets"]), download(row["bytes"]), download(row["dropped"]))
+		}
+	}
+	return queues
+}
+
+// download returns the second half of an upload/download counter pair such as "100/200"
+func download(pair string) string {
+	if _, d, ok := strings.Cut(pair, "/"); ok {
+		return d
+	}
+	return pair
+}
+
+// QoSGetFromMikrotik builds output queue state for the interfaces of a get filter from the queues
+// printed with stats
+func QoSGetFromMikrotik(filterXML string, replies Replies) *QoS {
+	var f struct {
+		QoS *QoS `xml:"qos"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	if f.QoS == nil || f.QoS.Interfaces == nil {
+		return nil
+	}
+	out := &QoS{Interfaces: &QoSInterfaces{}}
+	for _, i := range f.QoS.Interfaces.Interface {
+		if i.InterfaceID == nil || *i.InterfaceID == "" {
+			continue
+		}
+		id := *i.InterfaceID
+		queues := QoSInterfaceQueuesStateFromMikrotik(id, replies["/queue/simple/print stats="], replies["/queue/tree/print stats="])
+		out.Interfaces.Interface = append(out.Interfaces.Interface, QoSInterface{InterfaceID: &id, Output: &QoSInterfaceOutput{Queues: queues}})
+	}
+	return out
+}
@@ -0,0 +1,182 @@
+package openconfig
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// qosMangleManagedKeys are the mangle properties a classifier term manages
+var qosMangleManagedKeys = []string{"chain", "action", "dscp", "new-packet-mark", "passthrough"}
+
+// QoSToMikrotikCmds maps openconfig-qos onto RouterOS queues. Classifier terms matching a DSCP value
+// become /ip/firewall/mangle (or /ipv6/firewall/mangle) postrouting rules marking packets with the
+// output-queue of their target forwarding-group. An interface's output scheduler-policy is applied per
+// one-rate-two-color scheduler: schedulers with queue inputs become a /queue/tree entry per queue matching
+// its packet-mark, and the one scheduler without inputs a /queue/simple entry shaping the whole
+// interface's download side. cir becomes max-limit and bc becomes bucket-size, the burst expressed as a
+// ratio of max-limit.
+func QoSToMikrotikCmds(op string, qos *QoS, state *DeviceState) []string {
+	if qos == nil {
+		return nil
+	}
+	switch op {
+	case "get":
+		return []string{"/queue/simple/print stats=", "/queue/tree/print stats=", "/ip/firewall/mangle/print", "/ipv6/firewall/mangle/print"}
+	case "set":
+		var cmds []string
+		if qos.Classifiers != nil {
+			for _, c := range qos.Classifiers.Classifier {
+				cmds = append(cmds, qosClassifierCmds(qos, &c, state)...)
+			}
+		}
+		if qos.Interfaces != nil {
+			for _, i := range qos.Interfaces.Interface {
+				cmds = append(cmds, qosInterfaceCmds(qos, &i, state)...)
+			}
+		}
+		return cmds
+	}
+	return nil
+}
+
+// qosPacketMark resolves a forwarding-group to the packet-mark its traffic carries: the name of
+// its output-queue, or the group name itself when the group is not part of the request
+func qosPacketMark(qos *QoS, group string) string {
+	if qos.ForwardingGroups != nil {
+		for _, fg := range qos.ForwardingGroups.ForwardingGroup {
+			name := ""
+			if fg.Name != nil {
+				name = *fg.Name
+			} else if fg.Config != nil && fg.Config.Name != nil {
+				name = *fg.Config.Name
+			}
+			if name == group && fg.Config != nil && fg.Config.OutputQueue != nil && *fg.Config.OutputQueue != "" {
+				return *fg.Config.OutputQueue
+			}
+		}
+	}
+	return group
+}
+
+func qosClassifierCmds(qos *QoS, c *QoSClassifier, state *DeviceState) []string {
+	if c.Name == nil || *c.Name == "" {
+		return nil
+	}
+	var cmds []string
+	desired := map[string]map[string][]attr{"/ip/firewall/mangle": {}, "/ipv6/firewall/mangle": {}}
+	if c.Terms != nil {
+		for _, term := range c.Terms.Term {
+			if term.ID == nil || *term.ID == "" {
+				continue
+			}
+			menu, cond := "/ip/firewall/mangle", (*QoSIPCondition)(nil)
+			if term.Conditions != nil {
+				cond = term.Conditions.IPv4
+				if cond == nil && term.Conditions.IPv6 != nil {
+					menu, cond = "/ipv6/firewall/mangle", term.Conditions.IPv6
+				}
+			}
+			if cond == nil || cond.Config == nil || cond.Config.DSCP == nil {
+				cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: qos classifier %s term %s: only dscp matches are supported", *c.Name, *term.ID))
+				continue
+			}
+			if term.Actions == nil || term.Actions.Config == nil || term.Actions.Config.TargetGroup == nil {
+				cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: qos classifier %s term %s: missing target-group", *c.Name, *term.ID))
+				continue
+			}
+			desired[menu][qosTermComment(*c.Name, *term.ID)] = []attr{
+				{"chain", "postrouting"},
+				{"action", "mark-packet"},
+				{"dscp", strconv.Itoa(int(*cond.Config.DSCP))},
+				{"new-packet-mark", qosPacketMark(qos, *term.Actions.Config.TargetGroup)},
+				{"passthrough", "no"},
+			}
+		}
+	}
+	prefix := qosTermComment(*c.Name, "")
+	cmds = append(cmds, taggedRuleCmds(state, "/ip/firewall/mangle", prefix, desired["/ip/firewall/mangle"], qosMangleManagedKeys)...)
+	cmds = append(cmds, taggedRuleCmds(state, "/ipv6/firewall/mangle", prefix, desired["/ipv6/firewall/mangle"], qosMangleManagedKeys)...)
+	return cmds
+}
+
+func qosInterfaceCmds(qos *QoS, i *QoSInterface, state *DeviceState) []string {
+	if i.InterfaceID == nil || *i.InterfaceID == "" || i.Output == nil || i.Output.SchedulerPolicy == nil ||
+		i.Output.SchedulerPolicy.Config == nil || i.Output.SchedulerPolicy.Config.Name == nil {
+		return nil
+	}
+	iface, name := *i.InterfaceID, *i.Output.SchedulerPolicy.Config.Name
+	var policy *QoSSchedulerPolicy
+	if qos.SchedulerPolicies != nil {
+		for n := range qos.SchedulerPolicies.SchedulerPolicy {
+			if p := &qos.SchedulerPolicies.SchedulerPolicy[n]; p.Name != nil && *p.Name == name {
+				policy = p
+			}
+		}
+	}
+	if policy == nil {
+		return []string{fmt.Sprintf("UNSUPPORTED: qos interface %s: scheduler-policy %s must be configured in the same request", iface, name)}
+	}
+	if policy.Schedulers == nil {
+		return nil
+	}
+
+	schedulers := append([]QoSScheduler{}, policy.Schedulers.Scheduler...)
+	sort.SliceStable(schedulers, func(a, b int) bool { return schedulers[a].sequence() < schedulers[b].sequence() })
+	var cmds []string
+	shaped := false
+	for _, s := range schedulers {
+		if s.Config != nil && s.Config.Type != nil && identity(*s.Config.Type) != "ONE_RATE_TWO_COLOR" {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: qos scheduler-policy %s scheduler %d: type %s", name, s.sequence(), identity(*s.Config.Type)))
+			continue
+		}
+		if s.OneRateTwoColor == nil || s.OneRateTwoColor.Config == nil || s.OneRateTwoColor.Config.CIR == nil {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: qos scheduler-policy %s scheduler %d: missing one-rate-two-color cir", name, s.sequence()))
+			continue
+		}
+		cfg := s.OneRateTwoColor.Config
+		cir := strconv.FormatUint(*cfg.CIR, 10)
+		bucket := ""
+		if cfg.BC != nil && *cfg.CIR > 0 {
+			ratio := math.Round(float64(*cfg.BC)*8/float64(*cfg.CIR)*1000) / 1000
+			if ratio > 10 {
+				cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: qos scheduler-policy %s scheduler %d: bc exceeds 10 seconds of cir", name, s.sequence()))
+				continue
+			}
+			bucket = strconv.FormatFloat(ratio, 'f', -1, 64)
+		}
+
+		var queues []string
+		if s.Inputs != nil {
+			for _, in := range s.Inputs.Input {
+				if q := in.queue(); q != "" {
+					queues = append(queues, q)
+				}
+			}
+		}
+		if len(queues) == 0 {
+			// One simple queue shapes the whole interface, so only one scheduler can go without inputs
+			if shaped {
+				cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: qos scheduler-policy %s scheduler %d: only one scheduler without inputs per interface", name, s.sequence()))
+				continue
+			}
+			shaped = true
+			// Simple queue limits are upload/download as seen from the target; output is the download side
+			args := " target=" + iface + " max-limit=0/" + cir
+			if bucket != "" {
+				args += " bucket-size=0.1/" + bucket
+			}
+			cmds = append(cmds, upsertCmd(state, "/queue/simple", "name", qosSimpleQueueName(iface), args))
+			continue
+		}
+		for _, q := range queues {
+			args := " parent=" + iface + " packet-mark=" + q + " max-limit=" + cir
+			if bucket != "" {
+				args += " bucket-size=" + bucket
+			}
+			cmds = append(cmds, upsertCmd(state, "/queue/tree", "name", qosTreeQueueName(iface, q), args))
+		}
+	}
+	return cmds
+}
@@ -0,0 +1,183 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQoS_MikroTikCmd_Set_NoState(t *testing.T) {
+	classifier, term, group, queue := "dscp", "voice", "fg-voice", "voice"
+	dscp := uint8(46)
+	policy, iface := "cpe-10m", "ether2"
+	seq1, seq2 := uint32(1), uint32(2)
+	cir, bc := uint64(10000000), uint32(125000)
+	voiceCIR := uint64(2000000)
+	qos := &QoS{
+		Classifiers: &QoSClassifiers{Classifier: []QoSClassifier{{
+			Name: &classifier,
+			Terms: &QoSClassifierTerms{Term: []QoSClassifierTerm{{
+				ID:         &term,
+				Conditions: &QoSConditions{IPv4: &QoSIPCondition{Config: &QoSIPConditionConfig{DSCP: &dscp}}},
+				Actions:    &QoSActions{Config: &QoSActionsConfig{TargetGroup: &group}},
+			}}},
+		}}},
+		ForwardingGroups: &QoSForwardingGroups{ForwardingGroup: []QoSForwardingGroup{
+			{Name: &group, Config: &QoSForwardingGroupConfig{Name: &group, OutputQueue: &queue}},
+		}},
+		SchedulerPolicies: &QoSSchedulerPolicies{SchedulerPolicy: []QoSSchedulerPolicy{{
+			Name: &policy,
+			Schedulers: &QoSSchedulers{Scheduler: []QoSScheduler{
+				{
+					Sequence:        &seq2,
+					Inputs:          &QoSSchedulerInputs{Input: []QoSSchedulerInput{{Config: &QoSSchedulerInputConfig{Queue: &queue}}}},
+					OneRateTwoColor: &QoSOneRateTwoColor{Config: &QoSOneRateTwoColorConfig{CIR: &voiceCIR}},
+				},
+				{
+					Sequence:        &seq1,
+					OneRateTwoColor: &QoSOneRateTwoColor{Config: &QoSOneRateTwoColorConfig{CIR: &cir, BC: &bc}},
+				},
+			}},
+		}}},
+		Interfaces: &QoSInterfaces{Interface: []QoSInterface{{
+			InterfaceID: &iface,
+			Output:      &QoSInterfaceOutput{SchedulerPolicy: &QoSInterfaceSchedulerPolicy{Config: &QoSInterfaceSchedulerPolicyConfig{Name: &policy}}},
+		}}},
+	}
+	cmds := QoSToMikrotikCmds("set", qos, nil)
+	expected := []string{
+		`/ip/firewall/mangle/add chain=postrouting action=mark-packet dscp=46 new-packet-mark=voice passthrough=no comment="qos:dscp:voice"`,
+		"/queue/simple/add name=qos-ether2 target=ether2 max-limit=0/10000000 bucket-size=0.1/0.1",
+		"/queue/tree/add name=qos-ether2-voice parent=ether2 packet-mark=voice max-limit=2000000",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestQoS_MikroTikCmd_Set_Reconcile(t *testing.T) {
+	classifier, term, group, queue := "dscp", "voice", "fg-voice", "voice"
+	dscp := uint8(46)
+	policy, iface := "cpe-10m", "ether2"
+	seq1, seq2 := uint32(1), uint32(2)
+	cir, bc := uint64(10000000), uint32(125000)
+	voiceCIR := uint64(2000000)
+	qos := &QoS{
+		Classifiers: &QoSClassifiers{Classifier: []QoSClassifier{{
+			Name: &classifier,
+			Terms: &QoSClassifierTerms{Term: []QoSClassifierTerm{{
+				ID:         &term,
+				Conditions: &QoSConditions{IPv4: &QoSIPCondition{Config: &QoSIPConditionConfig{DSCP: &dscp}}},
+				Actions:    &QoSActions{Config: &QoSActionsConfig{TargetGroup: &group}},
+			}}},
+		}}},
+		ForwardingGroups: &QoSForwardingGroups{ForwardingGroup: []QoSForwardingGroup{
+			{Name: &group, Config: &QoSForwardingGroupConfig{Name: &group, OutputQueue: &queue}},
+		}},
+		SchedulerPolicies: &QoSSchedulerPolicies{SchedulerPolicy: []QoSSchedulerPolicy{{
+			Name: &policy,
+			Schedulers: &QoSSchedulers{Scheduler: []QoSScheduler{
+				{
+					Sequence:        &seq2,
+					Inputs:          &QoSSchedulerInputs{Input: []QoSSchedulerInput{{Config: &QoSSchedulerInputConfig{Queue: &queue}}}},
+					OneRateTwoColor: &QoSOneRateTwoColor{Config: &QoSOneRateTwoColorConfig{CIR: &voiceCIR}},
+				},
+				{
+					Sequence:        &seq1,
+					OneRateTwoColor: &QoSOneRateTwoColor{Config: &QoSOneRateTwoColorConfig{CIR: &cir, BC: &bc}},
+				},
+			}},
+		}}},
+		Interfaces: &QoSInterfaces{Interface: []QoSInterface{{
+			InterfaceID: &iface,
+			Output:      &QoSInterfaceOutput{SchedulerPolicy: &QoSInterfaceSchedulerPolicy{Config: &QoSInterfaceSchedulerPolicyConfig{Name: &policy}}},
+		}}},
+	}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/ip/firewall/mangle": {
+			{".id": "*1", "chain": "postrouting", "action": "mark-packet", "dscp": "46", "new-packet-mark": "voice", "passthrough": "no", "comment": "qos:dscp:voice"},
+			{".id": "*2", "chain": "postrouting", "action": "mark-packet", "dscp": "10", "new-packet-mark": "bulk", "passthrough": "no", "comment": "qos:dscp:bulk"},
+			{".id": "*3", "chain": "prerouting", "action": "mark-connection", "comment": "hotspot"},
+		},
+		"/queue/simple": {{".id": "*A", "name": "qos-ether2", "target": "ether2"}},
+	}}
+	cmds := QoSToMikrotikCmds("set", qos, state)
+	expected := []string{
+		"/ip/firewall/mangle/remove .id=*2",
+		"/queue/simple/set .id=*A target=ether2 max-limit=0/10000000 bucket-size=0.1/0.1",
+		"/queue/tree/add name=qos-ether2-voice parent=ether2 packet-mark=voice max-limit=2000000",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestQoS_MikroTikCmd_Set_UnknownPolicy(t *testing.T) {
+	iface, policy := "ether3", "missing"
+	qos := &QoS{Interfaces: &QoSInterfaces{Interface: []QoSInterface{{
+		InterfaceID: &iface,
+		Output:      &QoSInterfaceOutput{SchedulerPolicy: &QoSInterfaceSchedulerPolicy{Config: &QoSInterfaceSchedulerPolicyConfig{Name: &policy}}},
+	}}}}
+	cmds := QoSToMikrotikCmds("set", qos, nil)
+	expected := []string{"UNSUPPORTED: qos interface ether3: scheduler-policy missing must be configured in the same request"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestQoS_MikroTikCmd_Set_SecondSchedulerWithoutInputs(t *testing.T) {
+	policy, iface := "cpe", "ether2"
+	seq1, seq2 := uint32(1), uint32(2)
+	cir, burst := uint64(10000000), uint64(20000000)
+	qos := &QoS{
+		SchedulerPolicies: &QoSSchedulerPolicies{SchedulerPolicy: []QoSSchedulerPolicy{{
+			Name: &policy,
+			Schedulers: &QoSSchedulers{Scheduler: []QoSScheduler{
+				{Sequence: &seq1, OneRateTwoColor: &QoSOneRateTwoColor{Config: &QoSOneRateTwoColorConfig{CIR: &cir}}},
+				{Sequence: &seq2, OneRateTwoColor: &QoSOneRateTwoColor{Config: &QoSOneRateTwoColorConfig{CIR: &burst}}},
+			}},
+		}}},
+		Interfaces: &QoSInterfaces{Interface: []QoSInterface{{
+			InterfaceID: &iface,
+			Output:      &QoSInterfaceOutput{SchedulerPolicy: &QoSInterfaceSchedulerPolicy{Config: &QoSInterfaceSchedulerPolicyConfig{Name: &policy}}},
+		}}},
+	}
+	cmds := QoSToMikrotikCmds("set", qos, nil)
+	expected := []string{
+		"/queue/simple/add name=qos-ether2 target=ether2 max-limit=0/10000000",
+		"UNSUPPORTED: qos scheduler-policy cpe scheduler 2: only one scheduler without inputs per interface",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestQoSInterfaceQueuesStateFromMikrotik(t *testing.T) {
+	simple := []map[string]string{
+		{"name": "qos-ether2", "target": "ether2", "bytes": "100/2000", "packets": "1/20", "dropped": "0/3"},
+		{"name": "qos-ether3", "target": "ether3", "bytes": "5/5", "packets": "1/1", "dropped": "0/0"},
+	}
+	tree := []map[string]string{
+		{"name": "qos-ether2-voice", "parent": "ether2", "bytes": "640", "packets": "5", "dropped": "1"},
+		{"name": "other", "parent": "ether2", "bytes": "1", "packets": "1", "dropped": "0"},
+	}
+	queues := QoSInterfaceQueuesStateFromMikrotik("ether2", simple, tree)
+	if len(queues.Queue) != 2 {
+		t.Fatalf("expected 2 queues, got %d", len(queues.Queue))
+	}
+	voice := queues.Queue[0].State
+	if *voice.Name != "voice" || *voice.TransmitPkts != 5 || *voice.TransmitOctets != 640 || *voice.DroppedPkts != 1 {
+		t.Errorf("unexpected voice queue state %+v", voice)
+	}
+	all := queues.Queue[1].State
+	if *all.Name != "qos-ether2" || *all.TransmitPkts != 20 || *all.TransmitOctets != 2000 || *all.DroppedPkts != 3 {
+		t.Errorf("unexpected simple queue state %+v", all)
+	}
+}
+
+func TestQoS_MikroTikCmd_Get(t *testing.T) {
+	cmds := QoSGetToMikrotikCmds("<qos/>")
+	expected := []string{"/queue/simple/print stats=", "/queue/tree/print stats=", "/ip/firewall/mangle/print", "/ipv6/firewall/mangle/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
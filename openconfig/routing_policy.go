@@ -0,0 +1,145 @@
+package openconfig
+
+// RoutingPolicy represents the openconfig-routing-policy top-level container
+type RoutingPolicy struct {
+	DefinedSets       *DefinedSets       `xml:"defined-sets"`
+	PolicyDefinitions *PolicyDefinitions `xml:"policy-definitions"`
+}
+
+type DefinedSets struct {
+	PrefixSets *PrefixSets `xml:"prefix-sets"`
+}
+
+type PrefixSets struct {
+	PrefixSet []PrefixSet `xml:"prefix-set"`
+}
+
+type PrefixSet struct {
+	Name     *string          `xml:"name"`
+	Config   *PrefixSetConfig `xml:"config"`
+	Prefixes *PolicyPrefixes  `xml:"prefixes"`
+}
+
+type PrefixSetConfig struct {
+	Name *string `xml:"name"`
+}
+
+type PolicyPrefixes struct {
+	Prefix []PolicyPrefix `xml:"prefix"`
+}
+
+type PolicyPrefix struct {
+	IPPrefix        *string             `xml:"ip-prefix"`
+	MasklengthRange *string             `xml:"masklength-range"` // "exact" or e.g. "16..24"
+	Config          *PolicyPrefixConfig `xml:"config"`
+}
+
+type PolicyPrefixConfig struct {
+	IPPrefix        *string `xml:"ip-prefix"`
+	MasklengthRange *string `xml:"masklength-range"`
+}
+
+type PolicyDefinitions struct {
+	PolicyDefinition []PolicyDefinition `xml:"policy-definition"`
+}
+
+type PolicyDefinition struct {
+	Name       *string                 `xml:"name"`
+	Config     *PolicyDefinitionConfig `xml:"config"`
+	Statements *PolicyStatements       `xml:"statements"`
+}
+
+type PolicyDefinitionConfig struct {
+	Name *string `xml:"name"`
+}
+
+type PolicyStatements struct {
+	Statement []PolicyStatement `xml:"statement"`
+}
+
+type PolicyStatement struct {
+	Name       *string           `xml:"name"`
+	Conditions *PolicyConditions `xml:"conditions"`
+	Actions    *PolicyActions    `xml:"actions"`
+}
+
+type PolicyConditions struct {
+	MatchPrefixSet *MatchPrefixSet `xml:"match-prefix-set"`
+}
+
+type MatchPrefixSet struct {
+	Config *MatchPrefixSetConfig `xml:"config"`
+}
+
+type MatchPrefixSetConfig struct {
+	PrefixSet       *string `xml:"prefix-set"`
+	MatchSetOptions *string `xml:"match-set-options"` // ANY or INVERT
+}
+
+type PolicyActions struct {
+	Config     *PolicyActionsConfig `xml:"config"`
+	BGPActions *BGPActions          `xml:"bgp-actions"`
+}
+
+type PolicyActionsConfig struct {
+	PolicyResult *string `xml:"policy-result"` // ACCEPT_ROUTE or REJECT_ROUTE
+}
+
+type BGPActions struct {
+	Config *BGPActionsConfig `xml:"config"`
+}
+
+type BGPActionsConfig struct {
+	SetLocalPref *uint32 `xml:"set-local-pref"`
+	SetMED       *uint32 `xml:"set-med"`
+}
+
+// ApplyPolicy represents apply-policy as used by BGP neighbors
+type ApplyPolicy struct {
+	Config *ApplyPolicyConfig `xml:"config"`
+}
+
+type ApplyPolicyConfig struct {
+	ImportPolicy        []string `xml:"import-policy"`
+	ExportPolicy        []string `xml:"export-policy"`
+	DefaultImportPolicy *string  `xml:"default-import-policy"` // ACCEPT_ROUTE or REJECT_ROUTE
+	DefaultExportPolicy *string  `xml:"default-export-policy"`
+}
+
+// name returns the prefix-set key, falling back to config/name
+func (s *PrefixSet) name() string {
+	if s.Name != nil {
+		return *s.Name
+	}
+	if s.Config != nil && s.Config.Name != nil {
+		return *s.Config.Name
+	}
+	return ""
+}
+
+// name returns the policy-definition key, falling back to config/name
+func (d *PolicyDefinition) name() string {
+	if d.Name != nil {
+		return *d.Name
+	}
+	if d.Config != nil && d.Config.Name != nil {
+		return *d.Config.Name
+	}
+	return ""
+}
+
+// prefix returns the ip-prefix and masklength-range keys, falling back to config
+func (p *PolicyPrefix) prefix() (string, string) {
+	prefix, masklen := "", ""
+	if p.IPPrefix != nil {
+		prefix = *p.IPPrefix
+	} else if p.Config != nil && p.Config.IPPrefix != nil {
+		prefix = *p.Config.IPPrefix
+	}
+	if p.MasklengthRange != nil {
+		masklen = *p.MasklengthRange
+	} else if p.Config != nil && p.Config.MasklengthRange != nil {
+		masklen = *p.Config.MasklengthRange
+	}
+	return prefix, masklen
+}
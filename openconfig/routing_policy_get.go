@@ -0,0 +1,10 @@
+package openconfig
+
+// RoutingPolicyGetToMikrotikCmds parses the filter XML and dispatches to the routing-policy handler for get operations.
+func RoutingPolicyGetToMikrotikCmds(filterXML string) []string {
+	var f struct {
+		RoutingPolicy *RoutingPolicy `xml:"routing-policy"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	return RoutingPolicyToMikrotikCmds("get", f.RoutingPolicy, nil)
+}
@@ -0,0 +1,188 @@
+package openconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// policyResultActions maps OpenConfig policy-result identities onto route filter actions
+var policyResultActions = map[string]string{
+	"ACCEPT_ROUTE": "accept",
+	"REJECT_ROUTE": "reject",
+}
+
+// RoutingPolicyToMikrotikCmds translates each policy-definition into a RouterOS 7
+// /routing/filter/rule chain of the same name, one rule per statement in order. Prefix-sets
+// are inlined into each rule as "dst in" / "dst-len" matches. A definition with a statement that
+// cannot be translated is reported as unsupported and its chain on the device is left alone.
+func RoutingPolicyToMikrotikCmds(op string, rp *RoutingPolicy, state *DeviceState) []string {
+	if rp == nil {
+		return nil
+	}
+	switch op {
+	case "get":
+		// Route filter rules are free-form expressions with no reverse mapping, so a get is not answered
+		return []string{"UNSUPPORTED: routing-policy is not read back from /routing/filter/rule"}
+	case "set":
+		if rp.PolicyDefinitions == nil {
+			return nil
+		}
+		if state.majorVersion() < 7 {
+			return []string{"UNSUPPORTED: routing-policy is only supported on RouterOS 7 (/routing/filter/rule)"}
+		}
+
+		sets := map[string]*PrefixSet{}
+		if rp.DefinedSets != nil && rp.DefinedSets.PrefixSets != nil {
+			for i := range rp.DefinedSets.PrefixSets.PrefixSet {
+				set := &rp.DefinedSets.PrefixSets.PrefixSet[i]
+				sets[set.name()] = set
+			}
+		}
+
+		var cmds []string
+		for _, def := range rp.PolicyDefinitions.PolicyDefinition {
+			if def.name() == "" {
+				continue
+			}
+			rules, err := policyDefinitionRules(&def, sets)
+			if err != nil {
+				// A partial chain would change what the policy accepts, so the existing chain is kept
+				cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: policy-definition %s: %v", def.name(), err))
+				continue
+			}
+			cmds = append(cmds, filterChainCmds(state, def.name(), rules)...)
+		}
+		return cmds
+	}
+	return nil
+}
+
+// policyDefinitionRules renders the statements of a definition in order, failing on the first
+// statement that cannot be expressed as a route filter rule
+func policyDefinitionRules(def *PolicyDefinition, sets map[string]*PrefixSet) ([]string, error) {
+	if def.Statements == nil {
+		return nil, nil
+	}
+	var rules []string
+	for _, st := range def.Statements.Statement {
+		rule, err := policyStatementRule(&st, sets)
+		if err != nil {
+			return nil, err
+		}
+		if rule == "" {
+			continue
+		}
+		if st.Name != nil && *st.Name != "" {
+			rule += " comment=" + quote(*st.Name)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// policyStatementRule renders a statement as a quoted route filter rule argument,
+// e.g. rule="if (dst in 10.0.0.0/8 && dst-len in 8-24) { set bgp-local-pref 200; accept }"
+func policyStatementRule(st *PolicyStatement, sets map[string]*PrefixSet) (string, error) {
+	cond := ""
+	if c := st.Conditions; c != nil && c.MatchPrefixSet != nil && c.MatchPrefixSet.Config != nil && c.MatchPrefixSet.Config.PrefixSet != nil {
+		m := c.MatchPrefixSet.Config
+		set, ok := sets[*m.PrefixSet]
+		if !ok {
+			return "", fmt.Errorf("unknown prefix-set %s", *m.PrefixSet)
+		}
+		var terms []string
+		if set.Prefixes != nil {
+			for _, p := range set.Prefixes.Prefix {
+				prefix, masklen := p.prefix()
+				if prefix == "" {
+					continue
+				}
+				term := "dst in " + prefix
+				switch {
+				case masklen == "" || masklen == "exact":
+					if i := strings.Index(prefix, "/"); i >= 0 {
+						term += " && dst-len == " + prefix[i+1:]
+					}
+				case strings.Contains(masklen, ".."):
+					term += " && dst-len in " + strings.Replace(masklen, "..", "-", 1)
+				default:
+					return "", fmt.Errorf("invalid masklength-range %s", masklen)
+				}
+				terms = append(terms, "("+term+")")
+			}
+		}
+		if len(terms) == 0 {
+			return "", fmt.Errorf("prefix-set %s has no prefixes", *m.PrefixSet)
+		}
+		cond = strings.Join(terms, " || ")
+		if m.MatchSetOptions != nil && identity(*m.MatchSetOptions) == "INVERT" {
+			cond = "!(" + cond + ")"
+		}
+	}
+
+	var actions []string
+	if a := st.Actions; a != nil {
+		if a.BGPActions != nil && a.BGPActions.Config != nil {
+			if lp := a.BGPActions.Config.SetLocalPref; lp != nil {
+				actions = append(actions, fmt.Sprintf("set bgp-local-pref %d", *lp))
+			}
+			if med := a.BGPActions.Config.SetMED; med != nil {
+				actions = append(actions, fmt.Sprintf("set bgp-med %d", *med))
+			}
+		}
+		if a.Config != nil && a.Config.PolicyResult != nil {
+			action, ok := policyResultActions[identity(*a.Config.PolicyResult)]
+			if !ok {
+				return "", fmt.Errorf("unsupported policy-result %s", *a.Config.PolicyResult)
+			}
+			actions = append(actions, action)
+		}
+	}
+	if len(actions) == 0 {
+		return "", nil
+	}
+
+	body := strings.Join(actions, "; ")
+	if cond != "" {
+		body = "if (" + cond + ") { " + body + " }"
+	}
+	return "rule=" + quote(body), nil
+}
+
+// filterChainCmds replaces every rule of a /routing/filter/rule chain, since rule order is significant
+func filterChainCmds(state *DeviceState, chain string, rules []string) []string {
+	var cmds []string
+	for _, row := range state.table("/routing/filter/rule") {
+		if row["chain"] == chain {
+			cmds = append(cmds, "/routing/filter/rule/remove .id="+row[".id"])
+		}
+	}
+	for _, rule := range rules {
+		cmds = append(cmds, "/routing/filter/rule/add chain="+chain+" "+rule)
+	}
+	return cmds
+}
+
+// bgpPolicyChain returns the filter chain implementing one direction of a neighbor's apply-policy,
+// with any commands needed to build it. A single policy without a default is referenced directly;
+// otherwise the named chain jumps to each policy in turn and ends with the default action.
+func bgpPolicyChain(state *DeviceState, chain string, policies []string, def *string) (string, []string) {
+	if def == nil {
+		switch len(policies) {
+		case 0:
+			return "", nil
+		case 1:
+			return policies[0], nil
+		}
+	}
+	var rules []string
+	for _, p := range policies {
+		rules = append(rules, "rule="+quote("jump "+p))
+	}
+	if def != nil {
+		if action, ok := policyResultActions[identity(*def)]; ok {
+			rules = append(rules, "rule="+quote(action))
+		}
+	}
+	return chain, filterChainCmds(state, chain, rules)
+}
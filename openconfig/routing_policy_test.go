@@ -0,0 +1,111 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoutingPolicy_MikroTikCmd_Set(t *testing.T) {
+	setName, policy, stmt := "customers", "from-customers", "accept-customers"
+	p1, p2, exact, rng := "10.0.0.0/8", "192.0.2.0/24", "exact", "24..28"
+	invert, accept, reject := "INVERT", "ACCEPT_ROUTE", "oc-rpol:REJECT_ROUTE"
+	var localPref uint32 = 200
+	rp := &RoutingPolicy{
+		DefinedSets: &DefinedSets{PrefixSets: &PrefixSets{PrefixSet: []PrefixSet{{
+			Name: &setName,
+			Prefixes: &PolicyPrefixes{Prefix: []PolicyPrefix{
+				{IPPrefix: &p1, MasklengthRange: &exact},
+				{IPPrefix: &p2, MasklengthRange: &rng},
+			}},
+		}}}},
+		PolicyDefinitions: &PolicyDefinitions{PolicyDefinition: []PolicyDefinition{{
+			Name: &policy,
+			Statements: &PolicyStatements{Statement: []PolicyStatement{
+				{
+					Name:       &stmt,
+					Conditions: &PolicyConditions{MatchPrefixSet: &MatchPrefixSet{Config: &MatchPrefixSetConfig{PrefixSet: &setName}}},
+					Actions: &PolicyActions{
+						Config:     &PolicyActionsConfig{PolicyResult: &accept},
+						BGPActions: &BGPActions{Config: &BGPActionsConfig{SetLocalPref: &localPref}},
+					},
+				},
+				{
+					Conditions: &PolicyConditions{MatchPrefixSet: &MatchPrefixSet{Config: &MatchPrefixSetConfig{PrefixSet: &setName, MatchSetOptions: &invert}}},
+					Actions:    &PolicyActions{Config: &PolicyActionsConfig{PolicyResult: &reject}},
+				},
+			}},
+		}}},
+	}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/routing/filter/rule": {
+			{".id": "*1", "chain": "from-customers", "rule": "accept"},
+			{".id": "*2", "chain": "other", "rule": "reject"},
+		},
+	}}
+	cmds := RoutingPolicyToMikrotikCmds("set", rp, state)
+	expected := []string{
+		"/routing/filter/rule/remove .id=*1",
+		`/routing/filter/rule/add chain=from-customers rule="if ((dst in 10.0.0.0/8 && dst-len == 8) || (dst in 192.0.2.0/24 && dst-len in 24-28)) { set bgp-local-pref 200; accept }" comment="accept-customers"`,
+		`/routing/filter/rule/add chain=from-customers rule="if (!((dst in 10.0.0.0/8 && dst-len == 8) || (dst in 192.0.2.0/24 && dst-len in 24-28))) { reject }"`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestRoutingPolicy_MikroTikCmd_Set_UnknownPrefixSet(t *testing.T) {
+	setName, policy, accept := "customers", "from-customers", "ACCEPT_ROUTE"
+	rp := &RoutingPolicy{PolicyDefinitions: &PolicyDefinitions{PolicyDefinition: []PolicyDefinition{{
+		Name: &policy,
+		Statements: &PolicyStatements{Statement: []PolicyStatement{
+			{Actions: &PolicyActions{Config: &PolicyActionsConfig{PolicyResult: &accept}}},
+			{
+				Conditions: &PolicyConditions{MatchPrefixSet: &MatchPrefixSet{Config: &MatchPrefixSetConfig{PrefixSet: &setName}}},
+				Actions:    &PolicyActions{Config: &PolicyActionsConfig{PolicyResult: &accept}},
+			},
+		}},
+	}}}}
+	state := &DeviceState{Version: "7.12", Tables: map[string][]map[string]string{
+		"/routing/filter/rule": {{".id": "*1", "chain": "from-customers", "rule": "accept"}},
+	}}
+	cmds := RoutingPolicyToMikrotikCmds("set", rp, state)
+	expected := []string{"UNSUPPORTED: policy-definition from-customers: unknown prefix-set customers"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestBGP_MikroTikCmd_Set_ApplyPolicy(t *testing.T) {
+	addr, def := "198.51.100.1", "REJECT_ROUTE"
+	bgp := &BGP{Neighbors: &BGPNeighbors{Neighbor: []BGPNeighbor{{
+		NeighborAddress: &addr,
+		ApplyPolicy: &ApplyPolicy{Config: &ApplyPolicyConfig{
+			ImportPolicy:        []string{"from-customers"},
+			ExportPolicy:        []string{"bogons", "to-transit"},
+			DefaultExportPolicy: &def,
+		}},
+	}}}}
+	name, id := "DEFAULT", "oc-pol-types:BGP"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name:      &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{Identifier: &id, BGP: bgp}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, nil)
+	expected := []string{
+		`/routing/filter/rule/add chain=bgp-out-198.51.100.1 rule="jump bogons"`,
+		`/routing/filter/rule/add chain=bgp-out-198.51.100.1 rule="jump to-transit"`,
+		`/routing/filter/rule/add chain=bgp-out-198.51.100.1 rule="reject"`,
+		"/routing/bgp/connection/add name=198.51.100.1 remote.address=198.51.100.1 templates=default local.role=ebgp input.filter=from-customers output.filter=bgp-out-198.51.100.1",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestRoutingPolicyGetToMikrotikCmds(t *testing.T) {
+	cmds := RoutingPolicyGetToMikrotikCmds(`<routing-policy><policy-definitions/></routing-policy>`)
+	expected := []string{"UNSUPPORTED: routing-policy is not read back from /routing/filter/rule"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
@@ -0,0 +1,187 @@
+package openconfig
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DeviceState carries configuration read back from the device so handlers can
+// reconcile against existing entries instead of blindly adding them. A nil
+// *DeviceState is valid and means nothing is known about the device.
+type DeviceState struct {
+	// Version is the RouterOS version from /system/resource, e.g. "7.12.1 (stable)"
+	Version string
+	// Tables holds print replies keyed by menu path, e.g. "/ip/route"
+	Tables map[string][]map[string]string
+	// ManagementService is the /ip/service the translator is connected over, "api" or "api-ssl".
+	// When empty both are treated as the management connection.
+	ManagementService string
+	// ManagementSource is the address the device sees the translator connect from. When empty,
+	// any address allow-list on the management service is treated as excluding it.
+	ManagementSource string
+	// AllowManagementLockout lets an edit disable, move or restrict the management service
+	AllowManagementLockout bool
+}
+
+// reconciledTables lists the menus whose contents handlers diff against
+var reconciledTables = []string{
+	"/ip/route",
+	"/ipv6/route",
+	"/interface/bridge/vlan",
+	"/interface/bridge/port",
+	"/interface/bonding",
+	"/interface/ethernet",
+	"/ip/firewall/filter",
+	"/ipv6/firewall/filter",
+	"/ip/firewall/nat",
+	"/ip/firewall/mangle",
+	"/ipv6/firewall/mangle",
+	"/queue/simple",
+	"/queue/tree",
+	"/interface/list",
+	"/interface/list/member",
+	"/user",
+	"/user/group",
+	"/user/ssh-keys",
+	"/radius",
+	"/system/logging/action",
+	"/system/logging",
+	"/ip/dns/static",
+	"/ip/pool",
+	"/ip/dhcp-server",
+	"/ip/dhcp-server/network",
+	"/ip/dhcp-server/lease",
+	"/ip/service",
+}
+
+// reconciledTablesV7 lists menus that only exist on RouterOS 7
+var reconciledTablesV7 = []string{
+	"/ip/vrf",
+	"/routing/table",
+	"/routing/bgp/connection",
+	"/routing/bgp/template",
+	"/routing/bgp/vpn",
+	"/routing/ospf/instance",
+	"/routing/ospf/area",
+	"/routing/ospf/interface-template",
+	"/routing/filter/rule",
+	"/system/ntp/client/servers",
+}
+
+// reconciledTablesV6 lists menus that only exist on RouterOS 6
+var reconciledTablesV6 = []string{
+	"/ip/route/vrf",
+}
+
+// ReconciledTables returns the menus handlers diff against for this device's version.
+// Callers should print each of these into Tables before translating.
+func (s *DeviceState) ReconciledTables() []string {
+	tables := append([]string{}, reconciledTables...)
+	if s.majorVersion() >= 7 {
+		tables = append(tables, reconciledTablesV7...)
+	} else {
+		tables = append(tables, reconciledTablesV6...)
+	}
+	return tables
+}
+
+// managementServices returns the /ip/service entries an edit must not disable
+func (s *DeviceState) managementServices() []string {
+	if s == nil || s.ManagementService == "" {
+		return []string{"api", "api-ssl"}
+	}
+	return []string{s.ManagementService}
+}
+
+// table returns the rows read from a menu, or nil if nothing is known
+func (s *DeviceState) table(path string) []map[string]string {
+	if s == nil {
+		return nil
+	}
+	return s.Tables[path]
+}
+
+// majorVersion returns the RouterOS major version, assuming RouterOS 7 when unknown
+func (s *DeviceState) majorVersion() int {
+	if s == nil {
+		return 7
+	}
+	major, err := strconv.Atoi(strings.SplitN(s.Version, ".", 2)[0])
+	if err != nil {
+		return 7
+	}
+	return major
+}
+
+// find returns the row of a menu whose attr equals value, or nil if there is none
+func (s *DeviceState) find(path, attr, value string) map[string]string {
+	for _, row := range s.table(path) {
+		if row[attr] == value {
+			return row
+		}
+	}
+	return nil
+}
+
+// upsertCmd sets the row of menu whose keyAttr equals key, or adds one when the device has none
+func upsertCmd(state *DeviceState, menu, keyAttr, key, args string) string {
+	if row := state.find(menu, keyAttr, key); row != nil {
+		return menu + "/set .id=" + row[".id"] + args
+	}
+	return menu + "/add " + keyAttr + "=" + key + args
+}
+
+// removeCmds removes the row of menu whose keyAttr equals key. Without device state the row is
+// removed by a find selector; with state, nothing is removed when the device has no such row.
+func removeCmds(state *DeviceState, menu, keyAttr, key string) []string {
+	if row := state.find(menu, keyAttr, key); row != nil {
+		return []string{menu + "/remove .id=" + row[".id"]}
+	}
+	if state == nil {
+		return []string{menu + "/remove [find " + keyAttr + "=" + quote(key) + "]"}
+	}
+	return nil
+}
+
+// taggedRuleCmds reconciles the rules of an unordered menu that carry a comment tag starting
+// with prefix. desired maps each tag to the rule's attributes: unchanged rules are kept, changed
+// ones are removed and re-added, and tagged rules that are no longer desired are removed.
+func taggedRuleCmds(state *DeviceState, menu, prefix string, desired map[string][]attr, managed []string) []string {
+	existing := map[string]map[string]string{}
+	for _, row := range state.table(menu) {
+		if strings.HasPrefix(row["comment"], prefix) {
+			existing[row["comment"]] = row
+		}
+	}
+
+	tags := make([]string, 0, len(desired))
+	for tag := range desired {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	var cmds []string
+	for _, tag := range tags {
+		attrs := desired[tag]
+		if row, ok := existing[tag]; ok {
+			if rowMatches(row, attrs, managed) {
+				continue
+			}
+			cmds = append(cmds, menu+"/remove .id="+row[".id"])
+		}
+		cmds = append(cmds, menu+"/add"+renderAttrs(attrs)+" comment="+quote(tag))
+	}
+
+	var stale []string
+	for tag := range existing {
+		if _, ok := desired[tag]; !ok {
+			stale = append(stale, tag)
+		}
+	}
+	sort.Strings(stale)
+	for _, tag := range stale {
+		cmds = append(cmds, menu+"/remove .id="+existing[tag][".id"])
+	}
+	return cmds
+}
@@ -0,0 +1,65 @@
+package openconfig
+
+import "strings"
+
+// StaticRoutes represents protocols/protocol[STATIC]/static-routes
+type StaticRoutes struct {
+	Static []StaticRoute `xml:"static"`
+}
+
+type StaticRoute struct {
+	Prefix   *string            `xml:"prefix"`
+	Config   *StaticRouteConfig `xml:"config"`
+	State    *StaticRouteConfig `xml:"state"`
+	NextHops *StaticNextHops    `xml:"next-hops"`
+}
+
+type StaticRouteConfig struct {
+	Prefix *string `xml:"prefix"`
+}
+
+type StaticNextHops struct {
+	NextHop []StaticNextHop `xml:"next-hop"`
+}
+
+type StaticNextHop struct {
+	Index  *string              `xml:"index"`
+	Config *StaticNextHopConfig `xml:"config"`
+	State  *StaticNextHopConfig `xml:"state"`
+}
+
+type StaticNextHopConfig struct {
+	Index   *string `xml:"index"`
+	NextHop *string `xml:"next-hop"` // IP address, or the DROP identity for a blackhole route
+	Metric  *uint32 `xml:"metric"`
+}
+
+// prefix returns the static route key, falling back to config/prefix
+func (r *StaticRoute) prefix() string {
+	if r.Prefix != nil {
+		return *r.Prefix
+	}
+	if r.Config != nil && r.Config.Prefix != nil {
+		return *r.Config.Prefix
+	}
+	return ""
+}
+
+// index returns the next-hop key, falling back to config/index
+func (h *StaticNextHop) index() string {
+	if h.Index != nil {
+		return *h.Index
+	}
+	if h.Config != nil && h.Config.Index != nil {
+		return *h.Config.Index
+	}
+	return ""
+}
+
+// routeMenu returns the RouterOS route menu for an IPv4 or IPv6 prefix
+func routeMenu(prefix string) string {
+	if strings.Contains(prefix, ":") {
+		return "/ipv6/route"
+	}
+	return "/ip/route"
+}
@@ -0,0 +1,55 @@
+package openconfig
+
+import "strconv"
+
+// StaticRoutesFromMikrotik reverse-maps /ip/route/print and /ipv6/route/print rows in the given
+// routing table ("main" for the DEFAULT instance) into static-routes, filling both config and
+// state. Dynamic routes are skipped. Next-hops without a comment are indexed by their gateway.
+func StaticRoutesFromMikrotik(rows []map[string]string, table string) *StaticRoutes {
+	routes := &StaticRoutes{}
+	byPrefix := map[string]int{}
+	for _, row := range rows {
+		prefix := row["dst-address"]
+		if prefix == "" || row["dynamic"] == "true" || rowRoutingTable(row) != table {
+			continue
+		}
+		i, ok := byPrefix[prefix]
+		if !ok {
+			p := prefix
+			routes.Static = append(routes.Static, StaticRoute{
+				Prefix:   &p,
+				Config:   &StaticRouteConfig{Prefix: &p},
+				State:    &StaticRouteConfig{Prefix: &p},
+				NextHops: &StaticNextHops{},
+			})
+			i = len(routes.Static) - 1
+			byPrefix[prefix] = i
+		}
+
+		nextHop := routeNextHopKey(row)
+		index := row["comment"]
+		if index == "" {
+			index = nextHop
+		}
+		cfg := &StaticNextHopConfig{Index: &index, NextHop: &nextHop}
+		if d, err := strconv.ParseUint(row["distance"], 10, 32); err == nil {
+			metric := uint32(d)
+			cfg.Metric = &metric
+		}
+		state := *cfg
+		r := &routes.Static[i]
+		r.NextHops.NextHop = append(r.NextHops.NextHop, StaticNextHop{Index: &index, Config: cfg, State: &state})
+	}
+	return routes
+}
+
+// rowRoutingTable returns the routing table of a route row, reading routing-mark on RouterOS 6
+func rowRoutingTable(row map[string]string) string {
+	if t := row["routing-table"]; t != "" {
+		return t
+	}
+	if t := row["routing-mark"]; t != "" {
+		return t
+	}
+	return "main"
+}
@@ -0,0 +1,151 @@
+package openconfig
+
+import "fmt"
+
+// handleStaticRoutes maps static-routes/static[prefix]/next-hops/next-hop onto /ip/route and
+// /ipv6/route (dst-address, gateway, distance, with the next-hop index kept as comment).
+// Existing static routes in state are reconciled by prefix+next-hop index: matching routes are
+// updated in place (a removed metric resets the distance), and routes for a configured prefix
+// whose index is no longer listed are removed. Prefixes absent from the config are left untouched. Routes in an L3VRF
+// instance are added to, and reconciled within, that instance's routing table.
+func handleStaticRoutes(op string, ni *NetworkInstance, p *Protocol, state *DeviceState) []string {
+	switch op {
+	case "get":
+		return []string{"/ip/route/print", "/ipv6/route/print"}
+	case "set":
+		if p.StaticRoutes == nil {
+			return nil
+		}
+		var cmds []string
+		for _, r := range p.StaticRoutes.Static {
+			if r.prefix() == "" {
+				continue
+			}
+			cmds = append(cmds, staticRouteCmds(&r, ni, state)...)
+		}
+		return cmds
+	}
+	return nil
+}
+
+func staticRouteCmds(r *StaticRoute, ni *NetworkInstance, state *DeviceState) []string {
+	prefix := r.prefix()
+	menu := routeMenu(prefix)
+
+	// Routes outside the DEFAULT instance are scoped to its routing table,
+	// which RouterOS 6 expresses as a routing-mark
+	tableAttr := "routing-table"
+	if state.majorVersion() < 7 {
+		tableAttr = "routing-mark"
+	}
+	table := ni.routingTable()
+	scope := ""
+	if table != "main" {
+		if tableAttr == "routing-mark" && menu == "/ipv6/route" {
+			// RouterOS 6 policy routing is IPv4 only: /ipv6/route has no routing-mark
+			return []string{"UNSUPPORTED: network-instance " + table + " static route " + prefix + " is IPv6, which RouterOS 6 cannot scope to a routing-mark"}
+		}
+		scope = " " + tableAttr + "=" + table
+	}
+
+	// Existing static routes for this prefix and table, keyed by next-hop index
+	existing := map[string]map[string]string{}
+	var order []string
+	for _, row := range state.table(menu) {
+		if row["dst-address"] != prefix || row["dynamic"] == "true" || rowRoutingTable(row) != table {
+			continue
+		}
+		key := routeIndexKey(row)
+		existing[key] = row
+		order = append(order, key)
+	}
+
+	var cmds []string
+	wanted := map[string]bool{}
+	if r.NextHops != nil {
+		for _, h := range r.NextHops.NextHop {
+			if h.Config == nil || h.Config.NextHop == nil || *h.Config.NextHop == "" {
+				continue
+			}
+			target, nextHop := "gateway="+*h.Config.NextHop, *h.Config.NextHop
+			if identity(*h.Config.NextHop) == "DROP" {
+				// RouterOS 6 makes a blackhole a route type; RouterOS 7 has a blackhole flag
+				target, nextHop = "blackhole=yes", "DROP"
+				if state.majorVersion() < 7 {
+					target = "type=blackhole"
+				}
+			}
+			key := h.index()
+			if key == "" {
+				key = nextHop
+			}
+			wanted[key] = true
+
+			distance, comment := "", ""
+			if h.Config.Metric != nil {
+				distance = fmt.Sprintf(" distance=%d", *h.Config.Metric)
+			}
+			if idx := h.index(); idx != "" {
+				comment = " comment=" + quote(idx)
+			}
+			add := fmt.Sprintf("%s/add dst-address=%s %s%s%s%s", menu, prefix, target, scope, distance, comment)
+
+			row, ok := existing[key]
+			switch {
+			case !ok:
+				cmds = append(cmds, add)
+			case routeNextHopKey(row) != nextHop:
+				// The index moved to another next-hop: replace the route rather than switch
+				// between gateway and blackhole in place
+				cmds = append(cmds, fmt.Sprintf("%s/remove .id=%s", menu, row[".id"]), add)
+			case routeNeedsUpdate(row, h.Config, h.index()):
+				// A removed metric resets the route to the default distance
+				cmds = append(cmds, fmt.Sprintf("%s/set .id=%s distance=%d%s", menu, row[".id"], routeDistance(h.Config), comment))
+			}
+		}
+	}
+
+	for _, key := range order {
+		if !wanted[key] {
+			cmds = append(cmds, fmt.Sprintf("%s/remove .id=%s", menu, existing[key][".id"]))
+		}
+	}
+	return cmds
+}
+
+// routeNextHopKey returns the next-hop of a route row: its gateway, or DROP for a blackhole route,
+// which RouterOS 7 flags with blackhole and RouterOS 6 reports as type=blackhole
+func routeNextHopKey(row map[string]string) string {
+	if row["blackhole"] == "true" || row["type"] == "blackhole" {
+		return "DROP"
+	}
+	return row["gateway"]
+}
+
+// routeIndexKey identifies a route row the same way staticRouteCmds keys config: by the next-hop
+// index kept as comment, or by its next-hop when the route has no comment
+func routeIndexKey(row map[string]string) string {
+	if row["comment"] != "" {
+		return row["comment"]
+	}
+	return routeNextHopKey(row)
+}
+
+// defaultRouteDistance is the distance RouterOS gives static routes added without one
+const defaultRouteDistance = 1
+
+// routeDistance returns the distance a next-hop's metric maps to; without a metric the route
+// goes back to the RouterOS default distance for static routes
+func routeDistance(cfg *StaticNextHopConfig) uint32 {
+	if cfg.Metric != nil {
+		return *cfg.Metric
+	}
+	return defaultRouteDistance
+}
+
+func routeNeedsUpdate(row map[string]string, cfg *StaticNextHopConfig, index string) bool {
+	if row["distance"] != fmt.Sprint(routeDistance(cfg)) {
+		return true
+	}
+	return index != "" && row["comment"] != index
+}
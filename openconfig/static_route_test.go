@@ -0,0 +1,168 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStaticRoutes_MikroTikCmd_Set_NoState(t *testing.T) {
+	name, id, prefix := "DEFAULT", "STATIC", "10.0.0.0/8"
+	idx, addr := "0", "192.0.2.1"
+	var metric uint32 = 10
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name: &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{
+			Identifier: &id,
+			StaticRoutes: &StaticRoutes{Static: []StaticRoute{{
+				Prefix: &prefix,
+				NextHops: &StaticNextHops{NextHop: []StaticNextHop{
+					{Index: &idx, Config: &StaticNextHopConfig{Index: &idx, NextHop: &addr, Metric: &metric}},
+				}},
+			}}},
+		}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, nil)
+	expected := []string{`/ip/route/add dst-address=10.0.0.0/8 gateway=192.0.2.1 distance=10 comment="0"`}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestStaticRoutes_MikroTikCmd_Set_IPv6AndDrop(t *testing.T) {
+	name, id, prefix := "DEFAULT", "STATIC", "2001:db8::/32"
+	addr := "oc-loc-rt:DROP"
+	idx := "blackhole"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name: &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{
+			Identifier: &id,
+			StaticRoutes: &StaticRoutes{Static: []StaticRoute{{
+				Prefix: &prefix,
+				NextHops: &StaticNextHops{NextHop: []StaticNextHop{
+					{Index: &idx, Config: &StaticNextHopConfig{NextHop: &addr}},
+				}},
+			}}},
+		}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, nil)
+	expected := []string{`/ipv6/route/add dst-address=2001:db8::/32 blackhole=yes comment="blackhole"`}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+
+	// RouterOS 6 makes it a route type, and reads an existing blackhole route back as DROP
+	cmds = NetworkInstancesToMikrotikCmds("set", nis, &DeviceState{Version: "6.49.10"})
+	expected = []string{`/ipv6/route/add dst-address=2001:db8::/32 type=blackhole comment="blackhole"`}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+	cmds = NetworkInstancesToMikrotikCmds("set", nis, &DeviceState{Version: "6.49.10", Tables: map[string][]map[string]string{
+		"/ipv6/route": {{".id": "*1", "dst-address": "2001:db8::/32", "type": "blackhole", "distance": "1", "comment": "blackhole"}},
+	}})
+	if len(cmds) != 0 {
+		t.Errorf("expected no commands, got %v", cmds)
+	}
+}
+
+func TestStaticRoutes_MikroTikCmd_Set_Reconcile(t *testing.T) {
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/ip/route": {
+			{".id": "*1", "dst-address": "10.0.0.0/8", "gateway": "192.0.2.1", "distance": "10", "comment": "0"},
+			{".id": "*2", "dst-address": "10.0.0.0/8", "gateway": "192.0.2.2", "distance": "1", "comment": "1"},
+			{".id": "*3", "dst-address": "10.0.0.0/8", "gateway": "192.0.2.9", "distance": "1"},
+			{".id": "*4", "dst-address": "10.0.0.0/8", "gateway": "ether1", "dynamic": "true"},
+			{".id": "*5", "dst-address": "0.0.0.0/0", "gateway": "192.0.2.254"},
+		},
+	}}
+	name, id, prefix := "DEFAULT", "STATIC", "10.0.0.0/8"
+	idx0, idx1, idx2 := "0", "1", "2"
+	gw1, gw2, gw3 := "192.0.2.1", "192.0.2.2", "192.0.2.3"
+	var m10, m20, m1 uint32 = 10, 20, 1
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name: &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{
+			Identifier: &id,
+			StaticRoutes: &StaticRoutes{Static: []StaticRoute{{
+				Prefix: &prefix,
+				NextHops: &StaticNextHops{NextHop: []StaticNextHop{
+					{Index: &idx0, Config: &StaticNextHopConfig{Index: &idx0, NextHop: &gw1, Metric: &m10}}, // unchanged
+					{Index: &idx1, Config: &StaticNextHopConfig{Index: &idx1, NextHop: &gw2, Metric: &m20}}, // distance changed
+					{Index: &idx2, Config: &StaticNextHopConfig{Index: &idx2, NextHop: &gw3, Metric: &m1}},  // new
+				}},
+			}}},
+		}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, state)
+	expected := []string{
+		`/ip/route/set .id=*2 distance=20 comment="1"`,
+		`/ip/route/add dst-address=10.0.0.0/8 gateway=192.0.2.3 distance=1 comment="2"`,
+		"/ip/route/remove .id=*3",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestStaticRoutes_MikroTikCmd_Set_SameGatewayAndMetricReset(t *testing.T) {
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/ip/route": {
+			{".id": "*1", "dst-address": "10.0.0.0/8", "gateway": "192.0.2.1", "distance": "10", "comment": "primary"},
+			{".id": "*2", "dst-address": "10.0.0.0/8", "gateway": "192.0.2.2", "distance": "1", "comment": "backup"},
+		},
+	}}
+	name, id, prefix := "DEFAULT", "STATIC", "10.0.0.0/8"
+	primary, backup, gw := "primary", "backup", "192.0.2.1"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name: &name,
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{
+			Identifier: &id,
+			StaticRoutes: &StaticRoutes{Static: []StaticRoute{{
+				Prefix: &prefix,
+				NextHops: &StaticNextHops{NextHop: []StaticNextHop{
+					{Index: &primary, Config: &StaticNextHopConfig{NextHop: &gw}}, // metric removed
+					{Index: &backup, Config: &StaticNextHopConfig{NextHop: &gw}},  // moved to the same gateway
+				}},
+			}}},
+		}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, state)
+	expected := []string{
+		`/ip/route/set .id=*1 distance=1 comment="primary"`,
+		"/ip/route/remove .id=*2",
+		`/ip/route/add dst-address=10.0.0.0/8 gateway=192.0.2.1 comment="backup"`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestNetworkInstancesGetToMikrotikCmds_StaticRoutes(t *testing.T) {
+	xml := `<network-instances><network-instance><name>DEFAULT</name><protocols><protocol><static-routes/></protocol></protocols></network-instance></network-instances>`
+	cmds := NetworkInstancesGetToMikrotikCmds(xml)
+	expected := []string{"/ip/route/print", "/ipv6/route/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestStaticRoutesFromMikrotik(t *testing.T) {
+	routes := StaticRoutesFromMikrotik([]map[string]string{
+		{".id": "*1", "dst-address": "10.0.0.0/8", "gateway": "192.0.2.1", "distance": "10", "comment": "primary"},
+		{".id": "*2", "dst-address": "10.0.0.0/8", "gateway": "192.0.2.2", "distance": "20"},
+		{".id": "*3", "dst-address": "192.168.88.0/24", "gateway": "bridge", "dynamic": "true"},
+		{".id": "*4", "dst-address": "172.16.0.0/12", "gateway": "198.51.100.1", "routing-table": "cust-a"},
+	}, "main")
+	if len(routes.Static) != 1 {
+		t.Fatalf("expected 1 static route, got %d", len(routes.Static))
+	}
+	hops := routes.Static[0].NextHops.NextHop
+	if len(hops) != 2 {
+		t.Fatalf("expected 2 next-hops, got %d", len(hops))
+	}
+	if *hops[0].Index != "primary" || *hops[1].Index != "192.0.2.2" {
+		t.Errorf("unexpected indexes %q, %q", *hops[0].Index, *hops[1].Index)
+	}
+	if *hops[1].State.NextHop != "192.0.2.2" || *hops[1].State.Metric != 20 {
+		t.Errorf("unexpected state %+v", hops[1].State)
+	}
+}
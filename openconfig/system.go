@@ -20,13 +20,99 @@ func (h *SystemHostname) MikroTikCmd(op string) []string {
 }
 
 type System struct {
-	Hostname *string        `xml:"hostname"`
-	Clock    *SystemClock   `xml:"clock"`
-	NTP      *SystemNTP     `xml:"ntp"`
-	DNS      *SystemDNS     `xml:"dns"`
-	AAA      *SystemAAA     `xml:"aaa"`
-	Logging  *SystemLogging `xml:"logging"`
-	// Add more fields as needed (e.g., ssh, telnet, etc)
+	Hostname     *string             `xml:"hostname"`
+	Config       *SystemConfig       `xml:"config"`
+	Clock        *SystemClock        `xml:"clock"`
+	NTP          *SystemNTP          `xml:"ntp"`
+	DNS          *SystemDNS          `xml:"dns"`
+	AAA          *SystemAAA          `xml:"aaa"`
+	Logging      *SystemLogging      `xml:"logging"`
+	SSHServer    *SystemSSHServer    `xml:"ssh-server"`
+	TelnetServer *SystemTelnetServer `xml:"telnet-server"`
+	Memory       *SystemMemory       `xml:"memory"`
+	CPUs         *SystemCPUs         `xml:"cpus"`
+	Processes    *SystemProcesses    `xml:"processes"`
+	Alarms       *SystemAlarms       `xml:"alarms"`
+	// Services is a vendor augmentation covering every /ip/service entry
+	Services *SystemServices `xml:"services"`
+	// Add more fields as needed
+}
+
+type SystemConfig struct {
+	LoginBanner *string `xml:"login-banner"`
+	MotdBanner  *string `xml:"motd-banner"`
+}
+
+// SystemMemory and SystemCPUs are state only, read from /system/resource
+type SystemMemory struct {
+	State *SystemMemoryState `xml:"state"`
+}
+
+type SystemMemoryState struct {
+	Physical *uint64 `xml:"physical"`
+	Free     *uint64 `xml:"free"`
+	Used     *uint64 `xml:"used"`
+}
+
+type SystemCPUs struct {
+	CPU []SystemCPU `xml:"cpu"`
+}
+
+type SystemCPU struct {
+	Index *string         `xml:"index"`
+	State *SystemCPUState `xml:"state"`
+}
+
+type SystemCPUState struct {
+	Index *string               `xml:"index"`
+	Total *SystemCPUUtilization `xml:"total"`
+}
+
+// SystemCPUUtilization is a percentage; avg, min, max and interval (nanoseconds) are only set
+// when the load was sampled over a window
+type SystemCPUUtilization struct {
+	Instant  *uint8  `xml:"instant"`
+	Avg      *uint8  `xml:"avg"`
+	Min      *uint8  `xml:"min"`
+	Max      *uint8  `xml:"max"`
+	Interval *uint64 `xml:"interval"`
+}
+
+// SystemProcesses is state only. RouterOS has no processes with PIDs, so each /tool/profile
+// classifier is reported as a process with a synthetic pid (see processPID)
+type SystemProcesses struct {
+	Process []SystemProcess `xml:"process"`
+}
+
+type SystemProcess struct {
+	PID   *uint64             `xml:"pid"`
+	State *SystemProcessState `xml:"state"`
+}
+
+type SystemProcessState struct {
+	PID            *uint64 `xml:"pid"`
+	Name           *string `xml:"name"`
+	CPUUtilization *uint8  `xml:"cpu-utilization"`
+}
+
+// SystemAlarms is state only, derived from /system/health, /system/resource and the log
+type SystemAlarms struct {
+	Alarm []SystemAlarm `xml:"alarm"`
+}
+
+type SystemAlarm struct {
+	ID    *string           `xml:"id"`
+	State *SystemAlarmState `xml:"state"`
+}
+
+type SystemAlarmState struct {
+	ID       *string `xml:"id"`
+	Resource *string `xml:"resource"`
+	Text     *string `xml:"text"`
+	// TimeCreated is in nanoseconds since the Unix epoch
+	TimeCreated *uint64 `xml:"time-created"`
+	Severity    *string `xml:"severity"`
+	TypeID      *string `xml:"type-id"`
 }
 
 type SystemClock struct {
@@ -38,6 +124,25 @@ type SystemClock struct {
 type SystemNTP struct {
 	Servers *SystemNTPServers `xml:"servers"`
 	Enabled *bool             `xml:"enabled"`
+	Config  *SystemNTPConfig  `xml:"config"`
+	// NTPServer is a vendor augmentation for the RouterOS 7 NTP server
+	NTPServer *SystemNTPServerMode `xml:"ntp-server"`
+}
+
+type SystemNTPConfig struct {
+	Enabled          *bool   `xml:"enabled"`
+	NTPSourceAddress *string `xml:"ntp-source-address"`
+}
+
+type SystemNTPServerMode struct {
+	Config *SystemNTPServerModeConfig `xml:"config"`
+}
+
+type SystemNTPServerModeConfig struct {
+	Enabled            *bool    `xml:"enabled"`
+	Broadcast          *bool    `xml:"broadcast"`
+	Manycast           *bool    `xml:"manycast"`
+	BroadcastAddresses []string `xml:"broadcast-addresses"`
 }
 
 type SystemNTPServers struct {
@@ -45,25 +150,133 @@ type SystemNTPServers struct {
 }
 
 type SystemNTPServer struct {
+	Address *string                `xml:"address"`
+	Port    *uint16                `xml:"port"`
+	Config  *SystemNTPServerConfig `xml:"config"`
+	State   *SystemNTPServerState  `xml:"state"`
+}
+
+type SystemNTPServerConfig struct {
+	Address         *string `xml:"address"`
+	Port            *uint16 `xml:"port"`
+	Version         *uint8  `xml:"version"`
+	AssociationType *string `xml:"association-type"` // SERVER, PEER or POOL
+	Iburst          *bool   `xml:"iburst"`
+	Prefer          *bool   `xml:"prefer"`
+	SourceAddress   *string `xml:"source-address"`
+}
+
+type SystemNTPServerState struct {
 	Address *string `xml:"address"`
-	Port    *uint16 `xml:"port"`
-	// Add more fields as needed
+	Stratum *uint8  `xml:"stratum"`
+	Offset  *int64  `xml:"offset"` // milliseconds; signed, as the clock may be ahead or behind
+	// Status is a vendor augmentation carrying the RouterOS client status, e.g. "synchronized"
+	Status *string `xml:"status"`
 }
 
 type SystemDNS struct {
-	Servers *SystemDNSServers `xml:"servers"`
+	Config      *SystemDNSConfig      `xml:"config"`
+	Servers     *SystemDNSServers     `xml:"servers"`
+	HostEntries *SystemDNSHostEntries `xml:"host-entries"`
+}
+
+type SystemDNSConfig struct {
+	Search []string `xml:"search"`
+	// Vendor augmentation leaves
+	AllowRemoteRequests *bool   `xml:"allow-remote-requests"`
+	CacheSize           *uint32 `xml:"cache-size"` // KiB
 }
 
 type SystemDNSServers struct {
-	Server []string `xml:"server"`
+	Server []SystemDNSServer `xml:"server"`
+}
+
+// SystemDNSServer accepts both the OpenConfig list entry and a bare <server>address</server>
+type SystemDNSServer struct {
+	Value   string                 `xml:",chardata"`
+	Address *string                `xml:"address"`
+	Config  *SystemDNSServerConfig `xml:"config"`
+}
+
+type SystemDNSServerConfig struct {
+	Address *string `xml:"address"`
+	Port    *uint16 `xml:"port"`
+}
+
+type SystemDNSHostEntries struct {
+	HostEntry []SystemDNSHostEntry `xml:"host-entry"`
+}
+
+type SystemDNSHostEntry struct {
+	// Operation is the NETCONF edit-config operation attribute, e.g. "delete"
+	Operation *string                   `xml:"operation,attr"`
+	Hostname  *string                   `xml:"hostname"`
+	Config    *SystemDNSHostEntryConfig `xml:"config"`
+}
+
+type SystemDNSHostEntryConfig struct {
+	Hostname    *string  `xml:"hostname"`
+	Alias       []string `xml:"alias"`
+	IPv4Address []string `xml:"ipv4-address"`
+	IPv6Address []string `xml:"ipv6-address"`
 }
 
 type SystemAAA struct {
 	Authentication *SystemAuthentication `xml:"authentication"`
+	ServerGroups   *SystemServerGroups   `xml:"server-groups"`
 }
 
 type SystemAuthentication struct {
-	Users *SystemUsers `xml:"users"`
+	Config *SystemAuthenticationConfig `xml:"config"`
+	Users  *SystemUsers                `xml:"users"`
+}
+
+type SystemAuthenticationConfig struct {
+	// AuthenticationMethod is ordered: RADIUS_ALL, TACACS_ALL, LOCAL or a server-group name
+	AuthenticationMethod []string `xml:"authentication-method"`
+}
+
+type SystemServerGroups struct {
+	ServerGroup []SystemServerGroup `xml:"server-group"`
+}
+
+type SystemServerGroup struct {
+	Name    *string                  `xml:"name"`
+	Config  *SystemServerGroupConfig `xml:"config"`
+	Servers *SystemAAAServers        `xml:"servers"`
+}
+
+type SystemServerGroupConfig struct {
+	Name *string `xml:"name"`
+	Type *string `xml:"type"` // RADIUS or TACACS
+}
+
+type SystemAAAServers struct {
+	Server []SystemAAAServer `xml:"server"`
+}
+
+type SystemAAAServer struct {
+	Address *string                `xml:"address"`
+	Config  *SystemAAAServerConfig `xml:"config"`
+	Radius  *SystemAAARadius       `xml:"radius"`
+	Tacacs  *struct{}              `xml:"tacacs"`
+}
+
+type SystemAAAServerConfig struct {
+	Address *string `xml:"address"`
+	Name    *string `xml:"name"`
+	Timeout *uint16 `xml:"timeout"` // seconds
+}
+
+type SystemAAARadius struct {
+	Config *SystemAAARadiusConfig `xml:"config"`
+}
+
+type SystemAAARadiusConfig struct {
+	AuthPort      *uint16 `xml:"auth-port"`
+	AcctPort      *uint16 `xml:"acct-port"`
+	SecretKey     *string `xml:"secret-key"`
+	SourceAddress *string `xml:"source-address"`
 }
 
 type SystemUsers struct {
@@ -71,9 +284,20 @@ type SystemUsers struct {
 }
 
 type SystemUser struct {
-	Username *string `xml:"username"`
-	Password *string `xml:"password"`
-	Role     *string `xml:"role"`
+	// Operation is the NETCONF edit-config operation attribute, e.g. "delete"
+	Operation *string           `xml:"operation,attr"`
+	Username  *string           `xml:"username"`
+	Password  *string           `xml:"password"`
+	Role      *string           `xml:"role"`
+	Config    *SystemUserConfig `xml:"config"`
+}
+
+type SystemUserConfig struct {
+	Username       *string `xml:"username"`
+	Password       *string `xml:"password"`
+	PasswordHashed *string `xml:"password-hashed"`
+	SSHKey         *string `xml:"ssh-key"`
+	Role           *string `xml:"role"` // SYSTEM_ROLE_ADMIN or a custom role name
 }
 
 type SystemLogging struct {
@@ -82,7 +306,8 @@ type SystemLogging struct {
 }
 
 type SystemLoggingConsole struct {
-	Severity *string `xml:"severity"`
+	Severity  *string                 `xml:"severity"`
+	Selectors *SystemLoggingSelectors `xml:"selectors"`
 }
 
 type SystemLoggingRemoteServers struct {
@@ -90,7 +315,72 @@ type SystemLoggingRemoteServers struct {
 }
 
 type SystemLoggingRemoteServer struct {
-	Host     *string `xml:"host"`
-	Port     *uint16 `xml:"port"`
-	Severity *string `xml:"severity"`
+	Host      *string                          `xml:"host"`
+	Port      *uint16                          `xml:"port"`
+	Severity  *string                          `xml:"severity"`
+	Config    *SystemLoggingRemoteServerConfig `xml:"config"`
+	Selectors *SystemLoggingSelectors          `xml:"selectors"`
+}
+
+type SystemLoggingRemoteServerConfig struct {
+	Host          *string `xml:"host"`
+	SourceAddress *string `xml:"source-address"`
+	RemotePort    *uint16 `xml:"remote-port"`
+}
+
+type SystemLoggingSelectors struct {
+	Selector []SystemLoggingSelector `xml:"selector"`
+}
+
+type SystemLoggingSelector struct {
+	Facility *string                      `xml:"facility"`
+	Severity *string                      `xml:"severity"`
+	Config   *SystemLoggingSelectorConfig `xml:"config"`
+}
+
+type SystemLoggingSelectorConfig struct {
+	Facility *string `xml:"facility"` // e.g. ALL, AUTH, SYSTEM_DAEMON
+	Severity *string `xml:"severity"` // EMERGENCY ... DEBUG, the least severe level logged
+}
+
+type SystemSSHServer struct {
+	Config *SystemSSHServerConfig `xml:"config"`
+}
+
+type SystemSSHServerConfig struct {
+	Enable          *bool   `xml:"enable"`
+	ProtocolVersion *string `xml:"protocol-version"` // V2, V1 or V1_V2
+	Timeout         *uint16 `xml:"timeout"`          // idle timeout, seconds
+	RateLimit       *uint16 `xml:"rate-limit"`       // connections per minute
+	// Vendor augmentation leaves
+	Port         *uint16  `xml:"port"`
+	Address      []string `xml:"address"` // allowed client prefixes
+	StrongCrypto *bool    `xml:"strong-crypto"`
+	HostKeySize  *uint16  `xml:"host-key-size"`
+}
+
+type SystemTelnetServer struct {
+	Config *SystemTelnetServerConfig `xml:"config"`
+}
+
+type SystemTelnetServerConfig struct {
+	Enable *bool `xml:"enable"`
+}
+
+// SystemServices is a vendor augmentation listing RouterOS management services
+// (api, api-ssl, winbox, www, www-ssl, ftp, telnet, ssh) by name.
+type SystemServices struct {
+	Service []SystemService `xml:"service"`
+}
+
+type SystemService struct {
+	Name   *string              `xml:"name"`
+	Config *SystemServiceConfig `xml:"config"`
+}
+
+type SystemServiceConfig struct {
+	Name    *string  `xml:"name"`
+	Enabled *bool    `xml:"enabled"`
+	Port    *uint16  `xml:"port"`
+	Address []string `xml:"address"` // allowed client prefixes
 }
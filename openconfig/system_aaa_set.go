@@ -0,0 +1,292 @@
+package openconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// builtinUserGroups are the /user/group entries every RouterOS device has
+var builtinUserGroups = map[string]bool{"full": true, "write": true, "read": true}
+
+// userPrintCmd reads the local users without their passwords
+const userPrintCmd = "/user/print proplist=name,group,disabled,comment"
+
+// handleSystemAAA maps aaa/authentication/users/user onto /user. SYSTEM_ROLE_ADMIN becomes group
+// full and any other role the /user/group of the same name, which must already exist: a role
+// that is neither a built-in group nor one on the device is reported as unsupported and the user
+// is left alone, as creating the group would not give it the role's permissions. Without device
+// state the group is passed through and RouterOS rejects the user if it does not exist.
+// Users carrying a NETCONF operation="delete" or "remove" attribute are removed. Passwords are only
+// ever written: reads use a proplist without the password. password-hashed is only accepted with
+// crypt id 0 ("$0$<cleartext>"), which is set as the password.
+// RADIUS server-groups become /radius entries for the login service, and authentication-method
+// decides /user/aaa use-radius. RouterOS has no TACACS+ client, so TACACS is rejected. RADIUS
+// secrets are write-only like passwords.
+func handleSystemAAA(op string, sys *System, state *DeviceState) []string {
+	switch op {
+	case "get":
+		return []string{userPrintCmd, "/user/ssh-keys/print", "/radius/print proplist=address,service,authentication-port,accounting-port,timeout,src-address,comment", "/user/aaa/print"}
+	case "set":
+		var cmds []string
+		if sys.AAA.ServerGroups != nil {
+			for _, g := range sys.AAA.ServerGroups.ServerGroup {
+				cmds = append(cmds, systemServerGroupCmds(&g, state)...)
+			}
+		}
+		if auth := sys.AAA.Authentication; auth != nil {
+			if auth.Users != nil {
+				for _, u := range auth.Users.User {
+					cmds = append(cmds, systemUserCmds(&u, state)...)
+				}
+			}
+			if auth.Config != nil && len(auth.Config.AuthenticationMethod) > 0 {
+				cmds = append(cmds, systemAuthenticationMethodCmds(auth.Config.AuthenticationMethod, sys.AAA.ServerGroups))
+			}
+		}
+		return cmds
+	}
+	return nil
+}
+
+// systemServerGroupCmds reconciles the /radius entries of a server-group. Each entry is tagged
+// with an "aaa:<group>:<address>" comment; tagged entries of the group that are no longer listed are removed.
+func systemServerGroupCmds(g *SystemServerGroup, state *DeviceState) []string {
+	name := g.name()
+	if name == "" {
+		return nil
+	}
+	if g.isTACACS() {
+		return []string{fmt.Sprintf("UNSUPPORTED: system/aaa server-group %s: TACACS+ is not available on RouterOS, use RADIUS", name)}
+	}
+	if g.Servers == nil {
+		return nil
+	}
+
+	var cmds []string
+	desired := map[string]bool{}
+	for _, srv := range g.Servers.Server {
+		addr := srv.address()
+		if addr == "" {
+			continue
+		}
+		if srv.Tacacs != nil {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: system/aaa server-group %s server %s: TACACS+ is not available on RouterOS, use RADIUS", name, addr))
+			continue
+		}
+		tag := systemRadiusComment(name, addr)
+		desired[tag] = true
+		args := " service=login"
+		if srv.Radius != nil && srv.Radius.Config != nil {
+			rc := srv.Radius.Config
+			if rc.SecretKey != nil {
+				args += " secret=" + quote(*rc.SecretKey)
+			}
+			if rc.AuthPort != nil {
+				args += fmt.Sprintf(" authentication-port=%d", *rc.AuthPort)
+			}
+			if rc.AcctPort != nil {
+				args += fmt.Sprintf(" accounting-port=%d", *rc.AcctPort)
+			}
+			if rc.SourceAddress != nil && *rc.SourceAddress != "" {
+				args += " src-address=" + *rc.SourceAddress
+			}
+		}
+		if srv.Config != nil && srv.Config.Timeout != nil {
+			args += fmt.Sprintf(" timeout=%ds", *srv.Config.Timeout)
+		}
+		if row := state.find("/radius", "comment", tag); row != nil {
+			cmds = append(cmds, "/radius/set .id="+row[".id"]+" address="+addr+args)
+		} else {
+			cmds = append(cmds, "/radius/add address="+addr+args+" comment="+quote(tag))
+		}
+	}
+	for _, row := range state.table("/radius") {
+		if strings.HasPrefix(row["comment"], systemRadiusComment(name, "")) && !desired[row["comment"]] {
+			cmds = append(cmds, "/radius/remove .id="+row[".id"])
+		}
+	}
+	return cmds
+}
+
+// systemAuthenticationMethodCmds maps the ordered authentication-method list onto /user/aaa.
+// RouterOS always checks local users before RADIUS, so RADIUS may only follow LOCAL.
+func systemAuthenticationMethodCmds(methods []string, groups *SystemServerGroups) string {
+	radius, local := -1, -1
+	for n, m := range methods {
+		switch identity(m) {
+		case "LOCAL":
+			local = n
+		case "RADIUS_ALL":
+			radius = n
+		case "TACACS_ALL":
+			return "UNSUPPORTED: system/aaa authentication-method TACACS_ALL: TACACS+ is not available on RouterOS"
+		default:
+			// A server-group name; only RADIUS groups can be used
+			if g := groups.find(m); g != nil && g.isTACACS() {
+				return fmt.Sprintf("UNSUPPORTED: system/aaa authentication-method %s: TACACS+ is not available on RouterOS", m)
+			}
+			if radius < 0 {
+				radius = n
+			}
+		}
+	}
+	if radius >= 0 && local > radius {
+		return "UNSUPPORTED: system/aaa authentication-method: RouterOS always checks LOCAL before RADIUS"
+	}
+	return "/user/aaa/set use-radius=" + yesNo(radius >= 0)
+}
+
+// systemRadiusComment tags the /radius entry of a server-group member
+func systemRadiusComment(group, address string) string {
+	return "aaa:" + group + ":" + address
+}
+
+// name returns the server-group key, falling back to config/name
+func (g *SystemServerGroup) name() string {
+	if g.Name != nil {
+		return *g.Name
+	}
+	if g.Config != nil && g.Config.Name != nil {
+		return *g.Config.Name
+	}
+	return ""
+}
+
+// isTACACS reports whether the server-group is declared as a TACACS group
+func (g *SystemServerGroup) isTACACS() bool {
+	return g.Config != nil && g.Config.Type != nil && identity(*g.Config.Type) == "TACACS"
+}
+
+// find returns the server-group with the given name, or nil if there is none
+func (gs *SystemServerGroups) find(name string) *SystemServerGroup {
+	if gs == nil {
+		return nil
+	}
+	for n := range gs.ServerGroup {
+		if gs.ServerGroup[n].name() == name {
+			return &gs.ServerGroup[n]
+		}
+	}
+	return nil
+}
+
+// address returns the server key, falling back to config/address
+func (s *SystemAAAServer) address() string {
+	if s.Address != nil {
+		return *s.Address
+	}
+	if s.Config != nil && s.Config.Address != nil {
+		return *s.Config.Address
+	}
+	return ""
+}
+
+func systemUserCmds(u *SystemUser, state *DeviceState) []string {
+	name := u.username()
+	if name == "" {
+		return nil
+	}
+	existing := state.find("/user", "name", name)
+	if isDeleteOperation(u.Operation) {
+		if existing != nil {
+			return []string{"/user/remove .id=" + existing[".id"]}
+		}
+		if state == nil {
+			return []string{"/user/remove [find name=" + quote(name) + "]"}
+		}
+		return nil
+	}
+
+	var cmds []string
+	args := ""
+	if role := u.role(); role != "" {
+		group := systemUserGroup(role)
+		if state != nil && !builtinUserGroups[group] && state.find("/user/group", "name", group) == nil {
+			return []string{fmt.Sprintf("UNSUPPORTED: system/aaa user %s: role %s is not a user group on the device", name, role)}
+		}
+		args += " group=" + group
+	} else if existing == nil {
+		// RouterOS requires a group on add; default to the least privileged one
+		args += " group=read"
+	}
+	pw := u.password()
+	if u.Config != nil && u.Config.PasswordHashed != nil && *u.Config.PasswordHashed != "" {
+		// Crypt id 0 carries the password in cleartext; RouterOS cannot take any real hash
+		if hashed := *u.Config.PasswordHashed; strings.HasPrefix(hashed, "$0$") {
+			if pw == "" {
+				pw = strings.TrimPrefix(hashed, "$0$")
+			}
+		} else {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: system/aaa user %s: password-hashed, RouterOS only accepts cleartext passwords", name))
+		}
+	}
+	if pw != "" {
+		args += " password=" + quote(pw)
+	}
+	if args != "" {
+		cmds = append(cmds, upsertCmd(state, "/user", "name", name, args))
+	}
+	if u.Config != nil && u.Config.SSHKey != nil && *u.Config.SSHKey != "" {
+		cmds = append(cmds, systemUserSSHKeyCmds(name, *u.Config.SSHKey, state)...)
+	}
+	return cmds
+}
+
+// systemUserSSHKeyCmds adds an SSH public key to a user unless a key with the same owner comment is
+// already there. Keys can only be added inline on RouterOS 7; RouterOS 6 imports them from an uploaded file.
+func systemUserSSHKeyCmds(user, key string, state *DeviceState) []string {
+	if state.majorVersion() < 7 {
+		return []string{fmt.Sprintf("UNSUPPORTED: system/aaa user %s: ssh-key on RouterOS 6, upload the key and use /user/ssh-keys/import", user)}
+	}
+	// An OpenSSH public key is "<type> <base64> <comment>"; RouterOS reports the comment as key-owner
+	fields := strings.Fields(key)
+	if len(fields) >= 3 {
+		for _, row := range state.table("/user/ssh-keys") {
+			if row["user"] == user && row["key-owner"] == fields[2] {
+				return nil
+			}
+		}
+	}
+	return []string{fmt.Sprintf("/user/ssh-keys/add user=%s key=%s", user, quote(key))}
+}
+
+// systemUserGroup maps an OpenConfig role onto a /user/group name
+func systemUserGroup(role string) string {
+	if identity(role) == "SYSTEM_ROLE_ADMIN" {
+		return "full"
+	}
+	return role
+}
+
+// username returns the user key, falling back to config/username
+func (u *SystemUser) username() string {
+	if u.Username != nil {
+		return *u.Username
+	}
+	if u.Config != nil && u.Config.Username != nil {
+		return *u.Config.Username
+	}
+	return ""
+}
+
+// password returns config/password, falling back to the top-level password leaf
+func (u *SystemUser) password() string {
+	if u.Config != nil && u.Config.Password != nil {
+		return *u.Config.Password
+	}
+	if u.Password != nil {
+		return *u.Password
+	}
+	return ""
+}
+
+// role returns config/role, falling back to the top-level role leaf
+func (u *SystemUser) role() string {
+	if u.Config != nil && u.Config.Role != nil {
+		return *u.Config.Role
+	}
+	if u.Role != nil {
+		return *u.Role
+	}
+	return ""
+}
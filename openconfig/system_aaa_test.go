@@ -0,0 +1,213 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSystemAAA_MikroTikCmd_Set_NoState(t *testing.T) {
+	key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI ops@example.net"
+	ops, opsPass, admin := "ops", `pa"ss`, "oc-aaa-types:SYSTEM_ROLE_ADMIN"
+	noc, nocRole := "noc", "noc-ro"
+	sys := &System{AAA: &SystemAAA{Authentication: &SystemAuthentication{Users: &SystemUsers{User: []SystemUser{
+		{Username: &ops, Config: &SystemUserConfig{Username: &ops, Password: &opsPass, Role: &admin, SSHKey: &key}},
+		{Username: &noc, Config: &SystemUserConfig{Username: &noc, Role: &nocRole}},
+	}}}}}
+	cmds := handleSystemAAA("set", sys, nil)
+	expected := []string{
+		`/user/add name=ops group=full password="pa\"ss"`,
+		`/user/ssh-keys/add user=ops key="ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI ops@example.net"`,
+		"/user/add name=noc group=noc-ro",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemAAA_MikroTikCmd_Set_Reconcile(t *testing.T) {
+	state := &DeviceState{Version: "7.14 (stable)", Tables: map[string][]map[string]string{
+		"/user":          {{".id": "*1", "name": "ops", "group": "full"}, {".id": "*2", "name": "old", "group": "read"}},
+		"/user/group":    {{".id": "*A", "name": "noc-ro"}},
+		"/user/ssh-keys": {{".id": "*K", "user": "ops", "key-owner": "ops@example.net"}},
+	}}
+	key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI ops@example.net"
+	ops, opsPass, noc, nocRole, old, remove := "ops", "secret", "noc", "noc-ro", "old", "delete"
+	sys := &System{AAA: &SystemAAA{Authentication: &SystemAuthentication{Users: &SystemUsers{User: []SystemUser{
+		{Username: &ops, Config: &SystemUserConfig{Username: &ops, Password: &opsPass, SSHKey: &key}},
+		{Username: &noc, Config: &SystemUserConfig{Username: &noc, Role: &nocRole}},
+		{Username: &old, Operation: &remove, Config: &SystemUserConfig{Username: &old}},
+	}}}}}
+	cmds := handleSystemAAA("set", sys, state)
+	expected := []string{
+		`/user/set .id=*1 password="secret"`,
+		"/user/add name=noc group=noc-ro",
+		"/user/remove .id=*2",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemAAA_MikroTikCmd_Set_UnknownRole(t *testing.T) {
+	state := &DeviceState{Version: "7.14 (stable)", Tables: map[string][]map[string]string{
+		"/user/group": {{".id": "*A", "name": "noc-ro"}},
+	}}
+	noc, role := "noc", "noc-rw"
+	sys := &System{AAA: &SystemAAA{Authentication: &SystemAuthentication{Users: &SystemUsers{User: []SystemUser{
+		{Username: &noc, Config: &SystemUserConfig{Username: &noc, Role: &role}},
+	}}}}}
+	cmds := handleSystemAAA("set", sys, state)
+	expected := []string{"UNSUPPORTED: system/aaa user noc: role noc-rw is not a user group on the device"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemAAA_MikroTikCmd_Set_Unsupported(t *testing.T) {
+	hash, key := "$6$salt$hash", "ssh-rsa AAAAB3Nza admin@host"
+	name, role := "admin", "SYSTEM_ROLE_ADMIN"
+	sys := &System{AAA: &SystemAAA{Authentication: &SystemAuthentication{Users: &SystemUsers{User: []SystemUser{
+		{Username: &name, Config: &SystemUserConfig{Username: &name, Role: &role, PasswordHashed: &hash, SSHKey: &key}},
+	}}}}}
+	state := &DeviceState{Version: "6.49.10"}
+	cmds := handleSystemAAA("set", sys, state)
+	expected := []string{
+		"UNSUPPORTED: system/aaa user admin: password-hashed, RouterOS only accepts cleartext passwords",
+		"/user/add name=admin group=full",
+		"UNSUPPORTED: system/aaa user admin: ssh-key on RouterOS 6, upload the key and use /user/ssh-keys/import",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemAAA_ParseDeleteOperation(t *testing.T) {
+	var sys System
+	err := unmarshalFilter(`<system><aaa><authentication><users>`+
+		`<user xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0" nc:operation="delete"><username>old</username></user>`+
+		`</users></authentication></aaa></system>`, &struct {
+		System *System `xml:"system"`
+	}{&sys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds := handleSystemAAA("set", &sys, nil)
+	expected := []string{`/user/remove [find name="old"]`}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemUsersFromMikrotik(t *testing.T) {
+	rows := []map[string]string{
+		{"name": "admin", "group": "full", "password": "leaked"},
+		{"name": "noc", "group": "noc-ro"},
+	}
+	users := SystemUsersFromMikrotik(rows)
+	if len(users.User) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users.User))
+	}
+	if u := users.User[0]; *u.Config.Role != "SYSTEM_ROLE_ADMIN" || u.Password != nil || u.Config.Password != nil {
+		t.Errorf("unexpected admin user %+v", u.Config)
+	}
+	if u := users.User[1]; *u.Config.Role != "noc-ro" {
+		t.Errorf("unexpected noc role %s", *u.Config.Role)
+	}
+}
+
+func TestSystemGetToMikrotikCmds_AAA(t *testing.T) {
+	cmds := SystemGetToMikrotikCmds(`<system><aaa/></system>`)
+	expected := []string{
+		"/user/print proplist=name,group,disabled,comment",
+		"/user/ssh-keys/print",
+		"/radius/print proplist=address,service,authentication-port,accounting-port,timeout,src-address,comment",
+		"/user/aaa/print",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemAAA_MikroTikCmd_Set_CleartextHash(t *testing.T) {
+	name, hash := "ops", "$0$s3cret"
+	sys := &System{AAA: &SystemAAA{Authentication: &SystemAuthentication{Users: &SystemUsers{User: []SystemUser{
+		{Username: &name, Config: &SystemUserConfig{Username: &name, PasswordHashed: &hash}},
+	}}}}}
+	cmds := handleSystemAAA("set", sys, nil)
+	expected := []string{`/user/add name=ops group=read password="s3cret"`}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemAAA_MikroTikCmd_Set_RadiusServerGroups(t *testing.T) {
+	group, typ, secret := "corp", "oc-aaa:RADIUS", "s3cret"
+	addr1, addr2 := "10.0.0.10", "10.0.0.11"
+	auth1, auth2, timeout := uint16(1812), uint16(1645), uint16(3)
+	sys := &System{AAA: &SystemAAA{
+		ServerGroups: &SystemServerGroups{ServerGroup: []SystemServerGroup{{
+			Name:   &group,
+			Config: &SystemServerGroupConfig{Name: &group, Type: &typ},
+			Servers: &SystemAAAServers{Server: []SystemAAAServer{
+				{
+					Address: &addr1,
+					Config:  &SystemAAAServerConfig{Timeout: &timeout},
+					Radius:  &SystemAAARadius{Config: &SystemAAARadiusConfig{SecretKey: &secret, AuthPort: &auth1}},
+				},
+				{Address: &addr2, Radius: &SystemAAARadius{Config: &SystemAAARadiusConfig{SecretKey: &secret, AuthPort: &auth2}}},
+			}},
+		}}},
+		Authentication: &SystemAuthentication{Config: &SystemAuthenticationConfig{
+			AuthenticationMethod: []string{"oc-aaa-types:LOCAL", "corp"},
+		}},
+	}}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/radius": {
+			{".id": "*1", "address": "10.0.0.10", "comment": "aaa:corp:10.0.0.10"},
+			{".id": "*2", "address": "10.0.0.9", "comment": "aaa:corp:10.0.0.9"},
+			{".id": "*3", "address": "10.9.9.9", "service": "hotspot"},
+		},
+	}}
+	cmds := handleSystemAAA("set", sys, state)
+	expected := []string{
+		`/radius/set .id=*1 address=10.0.0.10 service=login secret="s3cret" authentication-port=1812 timeout=3s`,
+		`/radius/add address=10.0.0.11 service=login secret="s3cret" authentication-port=1645 comment="aaa:corp:10.0.0.11"`,
+		"/radius/remove .id=*2",
+		"/user/aaa/set use-radius=yes",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemAAA_MikroTikCmd_Set_AuthenticationMethodUnsupported(t *testing.T) {
+	tests := []struct {
+		methods  []string
+		expected string
+	}{
+		{[]string{"LOCAL"}, "/user/aaa/set use-radius=no"},
+		{[]string{"RADIUS_ALL", "LOCAL"}, "UNSUPPORTED: system/aaa authentication-method: RouterOS always checks LOCAL before RADIUS"},
+		{[]string{"LOCAL", "TACACS_ALL"}, "UNSUPPORTED: system/aaa authentication-method TACACS_ALL: TACACS+ is not available on RouterOS"},
+	}
+	for _, tt := range tests {
+		sys := &System{AAA: &SystemAAA{Authentication: &SystemAuthentication{Config: &SystemAuthenticationConfig{AuthenticationMethod: tt.methods}}}}
+		cmds := handleSystemAAA("set", sys, nil)
+		if !reflect.DeepEqual(cmds, []string{tt.expected}) {
+			t.Errorf("%v: expected [%s], got %v", tt.methods, tt.expected, cmds)
+		}
+	}
+}
+
+func TestSystemAAA_MikroTikCmd_Set_TacacsRejected(t *testing.T) {
+	name, typ := "tac", "oc-aaa:TACACS"
+	sys := &System{AAA: &SystemAAA{ServerGroups: &SystemServerGroups{ServerGroup: []SystemServerGroup{{
+		Name:    &name,
+		Config:  &SystemServerGroupConfig{Name: &name, Type: &typ},
+		Servers: &SystemAAAServers{Server: []SystemAAAServer{{}}},
+	}}}}}
+	cmds := handleSystemAAA("set", sys, nil)
+	expected := []string{"UNSUPPORTED: system/aaa server-group tac: TACACS+ is not available on RouterOS, use RADIUS"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
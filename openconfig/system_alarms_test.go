@@ -0,0 +1,71 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSystemGetToMikrotikCmds_Alarms(t *testing.T) {
+	cmds := SystemGetToMikrotikCmds(`<system><alarms/></system>`)
+	expected := []string{"/system/health/print", "/system/resource/print", "/log/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemAlarmsFromMikrotik(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	health := []map[string]string{
+		{"name": "cpu-temperature", "value": "88", "type": "C"},
+		{"name": "board-temperature1", "value": "45", "type": "C"},
+		{"name": "fan1-speed", "value": "0", "type": "RPM"},
+		{"name": "fan2-speed", "value": "4200", "type": "RPM"},
+		{"name": "psu1-state", "value": "fail"},
+		{"name": "psu2-state", "value": "ok"},
+	}
+	resource := map[string]string{"total-memory": "1000", "free-memory": "50", "total-hdd-space": "1000", "free-hdd-space": "500"}
+	logs := []map[string]string{
+		{".id": "*1A", "time": "2026-03-04 11:58:00", "topics": "system,error,critical", "message": "login failure for user admin"},
+		{".id": "*1B", "time": "11:59:00", "topics": "system,info", "message": "config changed"},
+	}
+	alarms := SystemAlarmsFromMikrotik(health, resource, logs, now)
+
+	type alarm struct{ id, severity, typeID string }
+	var got []alarm
+	for _, a := range alarms.Alarm {
+		got = append(got, alarm{*a.ID, *a.State.Severity, *a.State.TypeID})
+	}
+	expected := []alarm{
+		{"over-temperature:cpu-temperature", "CRITICAL", "EQPT"},
+		{"fan-failure:fan1-speed", "MAJOR", "EQPT"},
+		{"psu-failure:psu1-state", "CRITICAL", "EQPT"},
+		{"low-free-memory", "MAJOR", "low-free-memory"},
+		{"log:*1A", "CRITICAL", "system,error,critical"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if ts := *alarms.Alarm[0].State.TimeCreated; ts != uint64(now.UnixNano()) {
+		t.Errorf("expected health alarm created at %d, got %d", now.UnixNano(), ts)
+	}
+	logAlarm := alarms.Alarm[4].State
+	if *logAlarm.Text != "login failure for user admin" || *logAlarm.TimeCreated != uint64(now.Add(-2*time.Minute).UnixNano()) {
+		t.Errorf("unexpected log alarm: %s at %d", *logAlarm.Text, *logAlarm.TimeCreated)
+	}
+}
+
+func TestLogTime(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"2025-12-31 23:00:00": time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC),
+		"jan/01 08:30:00":     time.Date(2026, 1, 1, 8, 30, 0, 0, time.UTC),
+		"dec/30 08:30:00":     time.Date(2025, 12, 30, 8, 30, 0, 0, time.UTC),
+		"10:15:00":            time.Date(2026, 1, 2, 10, 15, 0, 0, time.UTC),
+	}
+	for v, expected := range tests {
+		if got, ok := logTime(v, now); !ok || !got.Equal(expected) {
+			t.Errorf("%s: expected %v, got %v", v, expected, got)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package openconfig
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestSystemBanner_MikroTikCmd_Set(t *testing.T) {
+	var sys System
+	err := xml.Unmarshal([]byte("<system><config>"+
+		"<login-banner>AUTHORIZED USE ONLY</login-banner>"+
+		"<motd-banner>Maintenance Sunday\r\nActivity is \"monitored\".\r\n</motd-banner>"+
+		"</config></system>"), &sys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds := handleSystemBanner("set", &sys, nil)
+	expected := []string{
+		"UNSUPPORTED: system/config login-banner, RouterOS has no pre-login banner",
+		`/system/note/set note="Maintenance Sunday\nActivity is \"monitored\"." show-at-login=yes`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemBanner_MikroTikCmd_Set_Empty(t *testing.T) {
+	empty := ""
+	cmds := handleSystemBanner("set", &System{Config: &SystemConfig{MotdBanner: &empty}}, nil)
+	expected := []string{`/system/note/set note="" show-at-login=no`}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemConfigFromMikrotik(t *testing.T) {
+	cfg := SystemConfigFromMikrotik(map[string]string{"note": "line 1\nline 2", "show-at-login": "true"})
+	if cfg.MotdBanner == nil || *cfg.MotdBanner != "line 1\nline 2" || cfg.LoginBanner != nil {
+		t.Errorf("unexpected banners %+v", cfg)
+	}
+	if hidden := SystemConfigFromMikrotik(map[string]string{"note": "draft", "show-at-login": "false"}); hidden.MotdBanner != nil {
+		t.Errorf("expected no banner for a hidden note, got %q", *hidden.MotdBanner)
+	}
+}
+
+func TestSystemGetToMikrotikCmds_Banner(t *testing.T) {
+	cmds := SystemGetToMikrotikCmds(`<system><config><motd-banner/></config></system>`)
+	expected := []string{"/system/note/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+	if cmds := SystemGetToMikrotikCmds(`<system><config><login-banner/></config></system>`); len(cmds) != 0 {
+		t.Errorf("expected no commands for login-banner, got %v", cmds)
+	}
+}
@@ -12,7 +12,7 @@ func TestSystemClock_MikroTikCmd_Set(t *testing.T) {
 			TimezoneName: &tz,
 		},
 	}
-	cmds := handleSystemClock("set", sys)
+	cmds := handleSystemClock("set", sys, nil)
 	expected := []string{"/system/clock/set", "=time-zone-name=America/New_York"}
 	if !reflect.DeepEqual(cmds, expected) {
 		t.Errorf("expected %v, got %v", expected, cmds)
@@ -23,7 +23,7 @@ func TestSystemClock_MikroTikCmd_Set_Empty(t *testing.T) {
 	sys := &System{
 		Clock: &SystemClock{},
 	}
-	cmds := handleSystemClock("set", sys)
+	cmds := handleSystemClock("set", sys, nil)
 	if cmds != nil && len(cmds) > 0 {
 		t.Errorf("expected no commands, got %v", cmds)
 	}
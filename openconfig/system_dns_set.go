@@ -0,0 +1,150 @@
+package openconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// handleSystemDNS maps dns onto /ip/dns (servers, plus the allow-remote-requests and cache-size
+// vendor leaves) and host-entries onto /ip/dns/static. Each host entry owns the static entries
+// tagged "dns:<hostname>": one per name (hostname and aliases) and address, reconciled as a whole.
+// RouterOS has no resolver search list and always queries servers on port 53.
+func handleSystemDNS(op string, sys *System, state *DeviceState) []string {
+	switch op {
+	case "get":
+		return []string{"/ip/dns/print", "/ip/dns/static/print"}
+	case "set":
+		var cmds []string
+		args := ""
+		if cfg := sys.DNS.Config; cfg != nil {
+			if len(cfg.Search) > 0 {
+				cmds = append(cmds, "UNSUPPORTED: system/dns search, RouterOS has no resolver search list")
+			}
+			if cfg.AllowRemoteRequests != nil {
+				args += " allow-remote-requests=" + yesNo(*cfg.AllowRemoteRequests)
+			}
+			if cfg.CacheSize != nil {
+				args += fmt.Sprintf(" cache-size=%d", *cfg.CacheSize)
+			}
+		}
+		if sys.DNS.Servers != nil && len(sys.DNS.Servers.Server) > 0 {
+			var servers []string
+			for _, s := range sys.DNS.Servers.Server {
+				addr := s.address()
+				if addr == "" {
+					continue
+				}
+				if s.Config != nil && s.Config.Port != nil && *s.Config.Port != 53 {
+					cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: system/dns server %s port %d, RouterOS only queries port 53", addr, *s.Config.Port))
+					continue
+				}
+				servers = append(servers, addr)
+			}
+			if len(servers) > 0 {
+				args = " servers=" + strings.Join(servers, ",") + args
+			}
+		}
+		if args != "" {
+			cmds = append(cmds, "/ip/dns/set"+args)
+		}
+		if sys.DNS.HostEntries != nil {
+			for _, e := range sys.DNS.HostEntries.HostEntry {
+				cmds = append(cmds, dnsHostEntryCmds(&e, state)...)
+			}
+		}
+		return cmds
+	}
+	return nil
+}
+
+// dnsStaticEntry is one name/address pair of /ip/dns/static
+type dnsStaticEntry struct {
+	name, address string
+}
+
+func dnsHostEntryCmds(e *SystemDNSHostEntry, state *DeviceState) []string {
+	host := e.hostname()
+	if host == "" {
+		return nil
+	}
+	var desired []dnsStaticEntry
+	deleting := isDeleteOperation(e.Operation)
+	if !deleting && e.Config != nil {
+		names := append([]string{host}, e.Config.Alias...)
+		addrs := append(append([]string{}, e.Config.IPv4Address...), e.Config.IPv6Address...)
+		for _, name := range names {
+			for _, addr := range addrs {
+				desired = append(desired, dnsStaticEntry{name, addr})
+			}
+		}
+	}
+
+	var cmds []string
+	tag := dnsHostEntryComment(host)
+	if state == nil {
+		// Nothing is known about the device, so clear whatever the entry left behind before re-adding
+		cmds = append(cmds, "/ip/dns/static/remove [find comment="+quote(tag)+"]")
+	}
+	have := map[dnsStaticEntry]bool{}
+	for _, row := range state.table("/ip/dns/static") {
+		if row["comment"] != tag {
+			continue
+		}
+		entry := dnsStaticEntry{row["name"], row["address"]}
+		if !have[entry] && dnsContains(desired, entry) {
+			have[entry] = true
+			continue
+		}
+		cmds = append(cmds, "/ip/dns/static/remove .id="+row[".id"])
+	}
+	for _, entry := range desired {
+		if !have[entry] {
+			have[entry] = true
+			cmds = append(cmds, fmt.Sprintf("/ip/dns/static/add name=%s address=%s comment=%s", entry.name, entry.address, quote(tag)))
+		}
+	}
+	return cmds
+}
+
+func dnsContains(entries []dnsStaticEntry, e dnsStaticEntry) bool {
+	for _, d := range entries {
+		if d == e {
+			return true
+		}
+	}
+	return false
+}
+
+// dnsHostEntryComment tags the /ip/dns/static entries of a host-entry
+func dnsHostEntryComment(hostname string) string {
+	return "dns:" + hostname
+}
+
+// dnsCacheSize parses an /ip/dns cache-size, which RouterOS 6 reports with a KiB suffix
+func dnsCacheSize(v string) (uint32, bool) {
+	n, err := strconv.ParseUint(strings.TrimSuffix(v, "KiB"), 10, 32)
+	return uint32(n), err == nil
+}
+
+// address returns the server key: the address leaf, config/address or the bare element text
+func (s *SystemDNSServer) address() string {
+	if s.Address != nil {
+		return *s.Address
+	}
+	if s.Config != nil && s.Config.Address != nil {
+		return *s.Config.Address
+	}
+	return strings.TrimSpace(s.Value)
+}
+
+// hostname returns the host-entry key, falling back to config/hostname
+func (e *SystemDNSHostEntry) hostname() string {
+	if e.Hostname != nil {
+		return *e.Hostname
+	}
+	if e.Config != nil && e.Config.Hostname != nil {
+		return *e.Config.Hostname
+	}
+	return ""
+}
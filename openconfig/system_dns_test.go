@@ -0,0 +1,105 @@
+package openconfig
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestSystemDNS_MikroTikCmd_Set(t *testing.T) {
+	var sys System
+	err := xml.Unmarshal([]byte(`<system><dns>
+		<config><allow-remote-requests>true</allow-remote-requests><cache-size>4096</cache-size></config>
+		<servers>
+			<server><address>1.1.1.1</address><config><address>1.1.1.1</address><port>53</port></config></server>
+			<server>9.9.9.9</server>
+			<server><address>10.0.0.53</address><config><port>5353</port></config></server>
+		</servers>
+		<host-entries><host-entry><hostname>nas</hostname><config>
+			<alias>files</alias><ipv4-address>192.168.88.20</ipv4-address><ipv6-address>fd00::20</ipv6-address>
+		</config></host-entry></host-entries>
+	</dns></system>`), &sys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds := handleSystemDNS("set", &sys, nil)
+	expected := []string{
+		"UNSUPPORTED: system/dns server 10.0.0.53 port 5353, RouterOS only queries port 53",
+		"/ip/dns/set servers=1.1.1.1,9.9.9.9 allow-remote-requests=yes cache-size=4096",
+		`/ip/dns/static/remove [find comment="dns:nas"]`,
+		`/ip/dns/static/add name=nas address=192.168.88.20 comment="dns:nas"`,
+		`/ip/dns/static/add name=nas address=fd00::20 comment="dns:nas"`,
+		`/ip/dns/static/add name=files address=192.168.88.20 comment="dns:nas"`,
+		`/ip/dns/static/add name=files address=fd00::20 comment="dns:nas"`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemDNS_MikroTikCmd_Set_HostEntryReconcile(t *testing.T) {
+	nas, printer, remove := "nas", "printer", "delete"
+	sys := &System{DNS: &SystemDNS{HostEntries: &SystemDNSHostEntries{HostEntry: []SystemDNSHostEntry{
+		{Hostname: &nas, Config: &SystemDNSHostEntryConfig{IPv4Address: []string{"192.168.88.20", "192.168.88.21"}}},
+		{Hostname: &printer, Operation: &remove},
+	}}}}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/ip/dns/static": {
+			{".id": "*1", "name": "nas", "address": "192.168.88.20", "comment": "dns:nas"},
+			{".id": "*2", "name": "files", "address": "192.168.88.20", "comment": "dns:nas"},
+			{".id": "*3", "name": "printer", "address": "192.168.88.30", "comment": "dns:printer"},
+			{".id": "*4", "name": "router.lan", "address": "192.168.88.1"},
+		},
+	}}
+	cmds := handleSystemDNS("set", sys, state)
+	expected := []string{
+		"/ip/dns/static/remove .id=*2",
+		`/ip/dns/static/add name=nas address=192.168.88.21 comment="dns:nas"`,
+		"/ip/dns/static/remove .id=*3",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemDNS_MikroTikCmd_Set_SearchUnsupported(t *testing.T) {
+	sys := &System{DNS: &SystemDNS{Config: &SystemDNSConfig{Search: []string{"example.net"}}}}
+	cmds := handleSystemDNS("set", sys, nil)
+	expected := []string{"UNSUPPORTED: system/dns search, RouterOS has no resolver search list"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemDNSFromMikrotik(t *testing.T) {
+	dns := map[string]string{"servers": "1.1.1.1,9.9.9.9", "allow-remote-requests": "true", "cache-size": "2048KiB"}
+	static := []map[string]string{
+		{"name": "nas", "address": "192.168.88.20", "comment": "dns:nas"},
+		{"name": "files", "address": "192.168.88.20", "comment": "dns:nas"},
+		{"name": "nas", "address": "fd00::20", "comment": "dns:nas"},
+		{"name": "router.lan", "address": "192.168.88.1", "type": "A"},
+		{"name": "alias.lan", "cname": "router.lan", "type": "CNAME"},
+	}
+	out := SystemDNSFromMikrotik(dns, static)
+	if len(out.Servers.Server) != 2 || out.Servers.Server[1].address() != "9.9.9.9" {
+		t.Errorf("unexpected servers %+v", out.Servers.Server)
+	}
+	if !*out.Config.AllowRemoteRequests || *out.Config.CacheSize != 2048 {
+		t.Errorf("unexpected config %+v", out.Config)
+	}
+	if len(out.HostEntries.HostEntry) != 2 {
+		t.Fatalf("expected 2 host entries, got %d", len(out.HostEntries.HostEntry))
+	}
+	nas := out.HostEntries.HostEntry[0].Config
+	if !reflect.DeepEqual(nas.Alias, []string{"files"}) || !reflect.DeepEqual(nas.IPv4Address, []string{"192.168.88.20"}) || !reflect.DeepEqual(nas.IPv6Address, []string{"fd00::20"}) {
+		t.Errorf("unexpected nas host entry %+v", nas)
+	}
+}
+
+func TestSystemGetToMikrotikCmds_DNS(t *testing.T) {
+	cmds := SystemGetToMikrotikCmds(`<system><dns/></system>`)
+	expected := []string{"/ip/dns/print", "/ip/dns/static/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
@@ -1,24 +1,54 @@
 package openconfig
 
 import (
-	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// systemGetFilter is the part of a get filter under <system> that selects what is read
+type systemGetFilter struct {
+	Hostname *struct{} `xml:"hostname"`
+	Clock    *struct {
+		TimezoneName      *struct{} `xml:"timezone-name"`
+		TimezoneUTCOffset *struct{} `xml:"timezone-utc-offset"`
+	} `xml:"clock"`
+	NTP *struct {
+		Enabled *struct{} `xml:"enabled"`
+		Servers *struct{} `xml:"servers"`
+	} `xml:"ntp"`
+	AAA      *struct{}       `xml:"aaa"`
+	Logging  *struct{}       `xml:"logging"`
+	SSH      *struct{}       `xml:"ssh-server"`
+	Services *SystemServices `xml:"services"`
+	Telnet   *struct{}       `xml:"telnet-server"`
+	DNS      *struct{}       `xml:"dns"`
+	Config   *struct {
+		MotdBanner *struct{} `xml:"motd-banner"`
+	} `xml:"config"`
+	Memory    *struct{} `xml:"memory"`
+	CPUs      *struct{} `xml:"cpus"`
+	Processes *struct{} `xml:"processes"`
+	Alarms    *struct{} `xml:"alarms"`
+}
+
+func parseSystemGetFilter(filterXML string) (systemGetFilter, bool) {
+	var f struct {
+		System *systemGetFilter `xml:"system"`
+	}
+	_ = unmarshalFilter(filterXML, &f)
+	if f.System == nil {
+		return systemGetFilter{}, false
+	}
+	return *f.System, true
+}
+
 // SystemGetToMikrotikCmds parses the filter XML and dispatches to the unified handler for get operations.
 func SystemGetToMikrotikCmds(filterXML string) []string {
-	type systemFilter struct {
-		Hostname *struct{} `xml:"hostname"`
-		Clock    *struct {
-			TimezoneName      *struct{} `xml:"timezone-name"`
-			TimezoneUTCOffset *struct{} `xml:"timezone-utc-offset"`
-		} `xml:"clock"`
-		NTP *struct {
-			Enabled *struct{} `xml:"enabled"`
-			Servers *struct{} `xml:"servers"`
-		} `xml:"ntp"`
-	}
-	var sys systemFilter
-	_ = xml.Unmarshal([]byte(filterXML), &sys)
+	sys, _ := parseSystemGetFilter(filterXML)
 
 	var cmds []string
 	if sys.Hostname != nil {
@@ -32,8 +62,578 @@ func SystemGetToMikrotikCmds(filterXML string) []string {
 	if sys.NTP != nil {
 		// Use the existing NTP handler for GET operations
 		ntpSys := &System{NTP: &SystemNTP{}}
-		cmds = append(cmds, handleSystemNTP("get", ntpSys)...)
+		cmds = append(cmds, handleSystemNTP("get", ntpSys, nil)...)
+	}
+	if sys.AAA != nil {
+		cmds = append(cmds, handleSystemAAA("get", &System{AAA: &SystemAAA{}}, nil)...)
+	}
+	if sys.Logging != nil {
+		cmds = append(cmds, handleSystemLogging("get", &System{Logging: &SystemLogging{}}, nil)...)
+	}
+	if sys.SSH != nil {
+		cmds = append(cmds, handleSystemSSHServer("get", &System{SSHServer: &SystemSSHServer{}}, nil)...)
+	} else if sys.Services != nil || sys.Telnet != nil {
+		// ssh-server already prints /ip/service, which also covers telnet
+		cmds = append(cmds, handleSystemServices("get", &System{Services: &SystemServices{}}, nil)...)
+	}
+	if sys.DNS != nil {
+		cmds = append(cmds, handleSystemDNS("get", &System{DNS: &SystemDNS{}}, nil)...)
+	}
+	if sys.Config != nil && sys.Config.MotdBanner != nil {
+		cmds = append(cmds, handleSystemBanner("get", &System{Config: &SystemConfig{}}, nil)...)
+	}
+	if sys.Memory != nil {
+		cmds = append(cmds, "/system/resource/print")
+	}
+	if sys.CPUs != nil {
+		cmds = append(cmds, "/system/resource/cpu/print")
+	}
+	if sys.Processes != nil {
+		// The profiler samples for the whole duration before replying
+		cmds = append(cmds, "/tool/profile duration="+processProfileDuration+" cpu=total")
+	}
+	if sys.Alarms != nil {
+		cmds = append(cmds, "/system/health/print", "/system/resource/print", "/log/print")
 	}
 	// Add more features as needed, using their unified handler
 	return cmds
 }
+
+// SystemGetFromMikrotik builds the system subtree a get filter selects from the replies to the commands
+// SystemGetToMikrotikCmds issued for it. Subtrees whose commands did not return anything are left out.
+func SystemGetFromMikrotik(filterXML string, replies Replies) *System {
+	f, ok := parseSystemGetFilter(filterXML)
+	if !ok {
+		return nil
+	}
+	sys := &System{}
+	if identity := replies.row("/system/identity/print"); f.Hostname != nil && identity != nil {
+		sys.Hostname = optional(identity, "name")
+	}
+	if clock := replies.row("/system/clock/print"); f.Clock != nil && clock != nil {
+		sys.Clock = &SystemClock{TimezoneName: optional(clock, "time-zone-name"), TimezoneUTCOffset: optional(clock, "gmt-offset")}
+	}
+	if client := replies.row("/system/ntp/client/print"); f.NTP != nil && client != nil {
+		sys.NTP = &SystemNTP{}
+		if v, ok := client["enabled"]; ok {
+			enabled := v == "true" || v == "yes"
+			sys.NTP.Enabled = &enabled
+		}
+		// RouterOS 6 has no monitor and reports the synchronization state on the client itself
+		monitor := replies.row("/system/ntp/client/monitor once=")
+		if monitor == nil {
+			monitor = client
+		}
+		if servers := SystemNTPStateFromMikrotik(monitor); len(servers.Server) > 0 {
+			sys.NTP.Servers = servers
+		}
+	}
+	if users := replies[userPrintCmd]; f.AAA != nil && users != nil {
+		sys.AAA = &SystemAAA{Authentication: &SystemAuthentication{Users: SystemUsersFromMikrotik(users)}}
+	}
+	if actions := replies["/system/logging/action/print"]; f.Logging != nil && actions != nil {
+		sys.Logging = SystemLoggingFromMikrotik(actions, replies["/system/logging/print"])
+	}
+	if services := replies["/ip/service/print"]; services != nil {
+		if f.SSH != nil {
+			sys.SSHServer = SystemSSHServerFromMikrotik(services, replies.row("/ip/ssh/print"))
+		}
+		if f.Telnet != nil {
+			sys.TelnetServer = SystemTelnetServerFromMikrotik(services)
+		}
+		if f.Services != nil {
+			sys.Services = SystemServicesFromMikrotik(services)
+			// A filter naming services selects just those entries
+			wanted := map[string]bool{}
+			for _, svc := range f.Services.Service {
+				if name := svc.name(); name != "" {
+					wanted[name] = true
+				}
+			}
+			if len(wanted) > 0 {
+				var selected []SystemService
+				for _, svc := range sys.Services.Service {
+					if wanted[svc.name()] {
+						selected = append(selected, svc)
+					}
+				}
+				sys.Services.Service = selected
+			}
+		}
+	}
+	if dns := replies.row("/ip/dns/print"); f.DNS != nil && dns != nil {
+		sys.DNS = SystemDNSFromMikrotik(dns, replies["/ip/dns/static/print"])
+	}
+	if note := replies.row("/system/note/print"); f.Config != nil && note != nil {
+		sys.Config = SystemConfigFromMikrotik(note)
+	}
+	resource := replies.row("/system/resource/print")
+	if f.Memory != nil && resource != nil {
+		sys.Memory = SystemMemoryFromMikrotik(resource)
+	}
+	if cpus := replies["/system/resource/cpu/print"]; f.CPUs != nil && cpus != nil {
+		sys.CPUs = SystemCPUsFromMikrotik([][]map[string]string{cpus}, 0)
+	}
+	if profile := replies["/tool/profile duration="+processProfileDuration+" cpu=total"]; f.Processes != nil && profile != nil {
+		sys.Processes = SystemProcessesFromMikrotik(profile)
+	}
+	if f.Alarms != nil && resource != nil {
+		sys.Alarms = SystemAlarmsFromMikrotik(replies["/system/health/print"], resource, replies["/log/print"], time.Now())
+	}
+	return sys
+}
+
+// SystemMemoryFromMikrotik maps /system/resource total-memory and free-memory (bytes) onto
+// memory/state physical and free; used is the difference.
+func SystemMemoryFromMikrotik(resource map[string]string) *SystemMemory {
+	state := &SystemMemoryState{}
+	total, errTotal := strconv.ParseUint(resource["total-memory"], 10, 64)
+	free, errFree := strconv.ParseUint(resource["free-memory"], 10, 64)
+	if errTotal == nil {
+		state.Physical = &total
+	}
+	if errFree == nil {
+		state.Free = &free
+	}
+	if errTotal == nil && errFree == nil && free <= total {
+		used := total - free
+		state.Used = &used
+	}
+	return &SystemMemory{State: state}
+}
+
+// SystemCPUsFromMikrotik maps /system/resource/cpu/print output onto cpus/cpu[index]/state/total,
+// indexed by the core number of the cpu name ("cpu0" → 0). Each sample is one print of the table;
+// the last sample is the instant load, and with more than one sample avg/min/max are taken over
+// all of them and interval is set to the sampling window.
+func SystemCPUsFromMikrotik(samples [][]map[string]string, window time.Duration) *SystemCPUs {
+	cpus := &SystemCPUs{}
+	loads := map[string][]uint8{}
+	var order []string
+	for _, rows := range samples {
+		for _, row := range rows {
+			index := strings.TrimPrefix(row["cpu"], "cpu")
+			load, err := strconv.ParseUint(row["load"], 10, 8)
+			if index == "" || err != nil {
+				continue
+			}
+			if _, ok := loads[index]; !ok {
+				order = append(order, index)
+			}
+			loads[index] = append(loads[index], uint8(load))
+		}
+	}
+	for _, index := range order {
+		idx, values := index, loads[index]
+		total := &SystemCPUUtilization{Instant: &values[len(values)-1]}
+		if len(samples) > 1 {
+			min, max, sum := values[0], values[0], 0
+			for _, v := range values {
+				if v < min {
+					min = v
+				}
+				if v > max {
+					max = v
+				}
+				sum += int(v)
+			}
+			avg := uint8(math.Round(float64(sum) / float64(len(values))))
+			interval := uint64(window.Nanoseconds())
+			total.Avg, total.Min, total.Max, total.Interval = &avg, &min, &max, &interval
+		}
+		cpus.CPU = append(cpus.CPU, SystemCPU{Index: &idx, State: &SystemCPUState{Index: &idx, Total: total}})
+	}
+	return cpus
+}
+
+// SystemUsersFromMikrotik maps /user rows onto aaa/authentication/users/user: group full becomes
+// SYSTEM_ROLE_ADMIN and any other group is reported as a custom role. Passwords are never read back,
+// and RouterOS does not return the public key material of ssh-keys.
+func SystemUsersFromMikrotik(rows []map[string]string) *SystemUsers {
+	users := &SystemUsers{}
+	for _, row := range rows {
+		name := row["name"]
+		if name == "" {
+			continue
+		}
+		cfg := &SystemUserConfig{Username: &name, Role: optional(row, "group")}
+		if cfg.Role != nil && *cfg.Role == "full" {
+			admin := "SYSTEM_ROLE_ADMIN"
+			cfg.Role = &admin
+		}
+		users.User = append(users.User, SystemUser{Username: &name, Config: cfg})
+	}
+	return users
+}
+
+// SystemLoggingFromMikrotik reverse-maps /system/logging/action entries of target=remote, and the
+// rules pointing at them, onto logging/remote-servers; rules of the echo action become console
+// selectors. Rules are folded back into one selector per facility at the least severe topic logged.
+func SystemLoggingFromMikrotik(actions, rules []map[string]string) *SystemLogging {
+	logging := &SystemLogging{
+		Console:       &SystemLoggingConsole{Selectors: loggingSelectorsFromRules(loggingConsoleAction, rules)},
+		RemoteServers: &SystemLoggingRemoteServers{},
+	}
+	for _, a := range actions {
+		if a["target"] != "remote" || a["remote"] == "" {
+			continue
+		}
+		host := a["remote"]
+		cfg := &SystemLoggingRemoteServerConfig{Host: &host, SourceAddress: optional(a, "src-address")}
+		if port, err := strconv.ParseUint(a["remote-port"], 10, 16); err == nil {
+			p := uint16(port)
+			cfg.RemotePort = &p
+		}
+		logging.RemoteServers.RemoteServer = append(logging.RemoteServers.RemoteServer, SystemLoggingRemoteServer{
+			Host:      &host,
+			Config:    cfg,
+			Selectors: loggingSelectorsFromRules(a["name"], rules),
+		})
+	}
+	return logging
+}
+
+// loggingSelectorsFromRules folds the rules of an action into one selector per facility
+func loggingSelectorsFromRules(action string, rules []map[string]string) *SystemLoggingSelectors {
+	facilities := map[string]string{}
+	for facility, topic := range loggingFacilityTopics {
+		// AUTH and AUTHPRIV share a topic; report the plain facility
+		if cur, ok := facilities[topic]; !ok || facility < cur {
+			facilities[topic] = facility
+		}
+	}
+	levels := map[string]int{}
+	var order []string
+	for _, row := range rules {
+		if row["action"] != action {
+			continue
+		}
+		facility, level := "ALL", -1
+		for _, t := range strings.Split(row["topics"], ",") {
+			if n := indexOf(loggingSeverityTopics, t); n >= 0 {
+				level = n
+			} else if f, ok := facilities[t]; ok {
+				facility = f
+			} else {
+				level = -2 // a topic with no OpenConfig equivalent
+				break
+			}
+		}
+		if level < 0 {
+			continue
+		}
+		if cur, ok := levels[facility]; !ok {
+			order = append(order, facility)
+			levels[facility] = level
+		} else if level > cur {
+			levels[facility] = level
+		}
+	}
+	names := []string{"CRITICAL", "ERROR", "WARNING", "INFORMATIONAL", "DEBUG"}
+	selectors := &SystemLoggingSelectors{}
+	for _, f := range order {
+		facility, severity := f, names[levels[f]]
+		selectors.Selector = append(selectors.Selector, SystemLoggingSelector{
+			Facility: &facility,
+			Severity: &severity,
+			Config:   &SystemLoggingSelectorConfig{Facility: &facility, Severity: &severity},
+		})
+	}
+	return selectors
+}
+
+// indexOf returns the position of s in list, or -1
+func indexOf(list []string, s string) int {
+	for n, v := range list {
+		if v == s {
+			return n
+		}
+	}
+	return -1
+}
+
+// SystemSSHServerFromMikrotik maps the ssh entry of /ip/service and the /ip/ssh settings onto
+// ssh-server/config. RouterOS only speaks SSHv2, so protocol-version is always V2.
+func SystemSSHServerFromMikrotik(services []map[string]string, ssh map[string]string) *SystemSSHServer {
+	v2 := "V2"
+	cfg := &SystemSSHServerConfig{ProtocolVersion: &v2}
+	for _, svc := range SystemServicesFromMikrotik(services).Service {
+		if svc.name() == "ssh" {
+			cfg.Enable, cfg.Port, cfg.Address = svc.Config.Enabled, svc.Config.Port, svc.Config.Address
+		}
+	}
+	if v, ok := ssh["strong-crypto"]; ok {
+		b := v == "yes" || v == "true"
+		cfg.StrongCrypto = &b
+	}
+	if size, err := strconv.ParseUint(ssh["host-key-size"], 10, 16); err == nil {
+		s := uint16(size)
+		cfg.HostKeySize = &s
+	}
+	return &SystemSSHServer{Config: cfg}
+}
+
+// SystemServicesFromMikrotik maps /ip/service rows onto the services vendor augmentation
+func SystemServicesFromMikrotik(rows []map[string]string) *SystemServices {
+	services := &SystemServices{}
+	for _, row := range rows {
+		name := row["name"]
+		if name == "" {
+			continue
+		}
+		enabled := row["disabled"] != "true" && row["disabled"] != "yes"
+		cfg := &SystemServiceConfig{Name: &name, Enabled: &enabled}
+		if port, err := strconv.ParseUint(row["port"], 10, 16); err == nil {
+			p := uint16(port)
+			cfg.Port = &p
+		}
+		if row["address"] != "" {
+			cfg.Address = strings.Split(row["address"], ",")
+		}
+		services.Service = append(services.Service, SystemService{Name: &name, Config: cfg})
+	}
+	return services
+}
+
+// SystemTelnetServerFromMikrotik maps the telnet entry of /ip/service onto telnet-server/config
+func SystemTelnetServerFromMikrotik(services []map[string]string) *SystemTelnetServer {
+	cfg := &SystemTelnetServerConfig{}
+	for _, svc := range SystemServicesFromMikrotik(services).Service {
+		if svc.name() == "telnet" {
+			cfg.Enable = svc.Config.Enabled
+		}
+	}
+	return &SystemTelnetServer{Config: cfg}
+}
+
+// SystemDNSFromMikrotik maps /ip/dns and /ip/dns/static onto the dns container. Static entries tagged
+// by a host-entry are folded back into it, other names become host entries of their own.
+// Only A and AAAA entries have an OpenConfig equivalent.
+func SystemDNSFromMikrotik(dns map[string]string, static []map[string]string) *SystemDNS {
+	out := &SystemDNS{Config: &SystemDNSConfig{}, Servers: &SystemDNSServers{}, HostEntries: &SystemDNSHostEntries{}}
+	if v, ok := dns["allow-remote-requests"]; ok {
+		b := v == "yes" || v == "true"
+		out.Config.AllowRemoteRequests = &b
+	}
+	if size, ok := dnsCacheSize(dns["cache-size"]); ok {
+		out.Config.CacheSize = &size
+	}
+	for _, addr := range strings.Split(dns["servers"], ",") {
+		if addr != "" {
+			addr := addr
+			out.Servers.Server = append(out.Servers.Server, SystemDNSServer{Address: &addr, Config: &SystemDNSServerConfig{Address: &addr}})
+		}
+	}
+
+	index := map[string]int{}
+	for _, row := range static {
+		if t := row["type"]; (t != "" && t != "A" && t != "AAAA") || row["name"] == "" || row["address"] == "" {
+			continue
+		}
+		host, tagged := strings.CutPrefix(row["comment"], "dns:")
+		if !tagged {
+			host = row["name"]
+		}
+		n, ok := index[host]
+		if !ok {
+			name := host
+			n = len(out.HostEntries.HostEntry)
+			index[host] = n
+			out.HostEntries.HostEntry = append(out.HostEntries.HostEntry, SystemDNSHostEntry{Hostname: &name, Config: &SystemDNSHostEntryConfig{Hostname: &name}})
+		}
+		cfg := out.HostEntries.HostEntry[n].Config
+		if row["name"] != host && indexOf(cfg.Alias, row["name"]) < 0 {
+			cfg.Alias = append(cfg.Alias, row["name"])
+		}
+		addr := row["address"]
+		if strings.Contains(addr, ":") {
+			if indexOf(cfg.IPv6Address, addr) < 0 {
+				cfg.IPv6Address = append(cfg.IPv6Address, addr)
+			}
+		} else if indexOf(cfg.IPv4Address, addr) < 0 {
+			cfg.IPv4Address = append(cfg.IPv4Address, addr)
+		}
+	}
+	return out
+}
+
+// SystemNTPStateFromMikrotik maps an /system/ntp/client/monitor once reply (or the client print on
+// RouterOS 6) onto the state of the server the clock is synchronized to: synced-server (last-update-from
+// on RouterOS 6), synced-stratum and system-offset (last-adjustment on RouterOS 6), plus the client status.
+func SystemNTPStateFromMikrotik(monitor map[string]string) *SystemNTPServers {
+	addr := monitor["synced-server"]
+	if addr == "" {
+		addr = monitor["last-update-from"]
+	}
+	if addr == "" {
+		return &SystemNTPServers{}
+	}
+	state := &SystemNTPServerState{Address: &addr, Status: optional(monitor, "status")}
+	if stratum, err := strconv.ParseUint(monitor["synced-stratum"], 10, 8); err == nil {
+		s := uint8(stratum)
+		state.Stratum = &s
+	}
+	offset := monitor["system-offset"]
+	if offset == "" {
+		offset = monitor["last-adjustment"]
+	}
+	if ms, ok := ntpOffsetMillis(offset); ok {
+		state.Offset = &ms
+	}
+	return &SystemNTPServers{Server: []SystemNTPServer{{Address: &addr, State: state}}}
+}
+
+// ntpOffsetMillis parses a RouterOS clock offset, either a duration such as "-1ms500us" or a
+// plain number of milliseconds, rounding to whole milliseconds
+func ntpOffsetMillis(v string) (int64, bool) {
+	if d, err := time.ParseDuration(v); err == nil {
+		return d.Round(time.Millisecond).Milliseconds(), true
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return int64(math.Round(f)), true
+	}
+	return 0, false
+}
+
+// SystemConfigFromMikrotik maps /system/note onto config/motd-banner. RouterOS has no pre-login
+// banner, so login-banner is never reported. A note that is not shown at login is no banner.
+func SystemConfigFromMikrotik(note map[string]string) *SystemConfig {
+	cfg := &SystemConfig{}
+	if text := note["note"]; text != "" && note["show-at-login"] != "false" && note["show-at-login"] != "no" {
+		cfg.MotdBanner = &text
+	}
+	return cfg
+}
+
+// processProfileDuration is how long /tool/profile samples for a processes get
+const processProfileDuration = "5s"
+
+// SystemProcessesFromMikrotik maps /tool/profile output onto processes/process state, one process per
+// classifier (e.g. "networking", "management") with its usage as cpu-utilization. The idle and total
+// classifiers are not processes and are left out. The profiler repeats its rows while it runs, so the
+// last row of each classifier wins.
+func SystemProcessesFromMikrotik(rows []map[string]string) *SystemProcesses {
+	procs := &SystemProcesses{}
+	index := map[string]int{}
+	for _, row := range rows {
+		name := row["name"]
+		if name == "" || name == "idle" || name == "total" {
+			continue
+		}
+		usage, err := strconv.ParseFloat(strings.TrimSuffix(row["usage"], "%"), 64)
+		if err != nil {
+			continue
+		}
+		n, ok := index[name]
+		if !ok {
+			pid, nameCopy := processPID(name), name
+			n = len(procs.Process)
+			index[name] = n
+			procs.Process = append(procs.Process, SystemProcess{PID: &pid, State: &SystemProcessState{PID: &pid, Name: &nameCopy}})
+		}
+		cpu := uint8(math.Min(math.Round(usage), 100))
+		procs.Process[n].State.CPUUtilization = &cpu
+	}
+	return procs
+}
+
+// processPID derives a stable synthetic pid from a profiler classifier name, so the same
+// classifier keeps its pid across reads and devices
+func processPID(name string) uint64 {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return uint64(h.Sum32())
+}
+
+// Thresholds for the alarms derived from /system/health and /system/resource. RouterOS does not
+// expose its own thresholds, so these are fixed defaults.
+const (
+	alarmTemperatureMajor    = 70.0 // °C
+	alarmTemperatureCritical = 85.0 // °C
+	alarmLowFreePercent      = 10   // free memory or disk below this percentage of the total
+)
+
+// SystemAlarmsFromMikrotik derives alarms/alarm state from the device:
+//   - /system/health temperatures above 70°C (MAJOR) or 85°C (CRITICAL), fans reporting a speed of 0
+//     or a state other than ok (MAJOR), and power supplies reporting a state other than ok
+//     (CRITICAL), all with type-id EQPT
+//   - /system/resource free memory or free disk below 10% of the total (MAJOR)
+//   - /log entries with the critical topic (CRITICAL), identified by their log .id
+//
+// RouterOS does not record when a condition started, so health and resource alarms are created at
+// now; log alarms use the time of the log entry, read relative to now when it lacks a date.
+func SystemAlarmsFromMikrotik(health []map[string]string, resource map[string]string, logs []map[string]string, now time.Time) *SystemAlarms {
+	alarms := &SystemAlarms{}
+	add := func(id, resource, text, severity, typeID string, created time.Time) {
+		ts := uint64(created.UnixNano())
+		alarms.Alarm = append(alarms.Alarm, SystemAlarm{ID: &id, State: &SystemAlarmState{
+			ID: &id, Resource: &resource, Text: &text, TimeCreated: &ts, Severity: &severity, TypeID: &typeID,
+		}})
+	}
+
+	for _, sensor := range healthSensors(health) {
+		name, value := sensor[0], sensor[1]
+		switch {
+		case strings.Contains(name, "temperature"):
+			t, err := strconv.ParseFloat(value, 64)
+			if err != nil || t < alarmTemperatureMajor {
+				continue
+			}
+			severity := "MAJOR"
+			if t >= alarmTemperatureCritical {
+				severity = "CRITICAL"
+			}
+			add("over-temperature:"+name, name, fmt.Sprintf("%s is %s°C", name, value), severity, "EQPT", now)
+		case strings.HasPrefix(name, "fan") && (strings.HasSuffix(name, "-speed") && value == "0" ||
+			strings.HasSuffix(name, "-state") && value != "ok"):
+			add("fan-failure:"+name, name, fmt.Sprintf("%s is %s", name, value), "MAJOR", "EQPT", now)
+		case strings.HasPrefix(name, "psu") && strings.HasSuffix(name, "-state") && value != "ok":
+			add("psu-failure:"+name, name, fmt.Sprintf("%s is %s", name, value), "CRITICAL", "EQPT", now)
+		}
+	}
+
+	for _, check := range []struct{ id, free, total, text string }{
+		{"low-free-memory", "free-memory", "total-memory", "free memory"},
+		{"low-free-disk", "free-hdd-space", "total-hdd-space", "free disk space"},
+	} {
+		free, errFree := strconv.ParseUint(resource[check.free], 10, 64)
+		total, errTotal := strconv.ParseUint(resource[check.total], 10, 64)
+		if errFree != nil || errTotal != nil || total == 0 || free*100 >= total*alarmLowFreePercent {
+			continue
+		}
+		add(check.id, "system", fmt.Sprintf("%s is %d of %d bytes", check.text, free, total), "MAJOR", check.id, now)
+	}
+
+	for _, entry := range logs {
+		topics := strings.Split(entry["topics"], ",")
+		if indexOf(topics, "critical") < 0 || entry[".id"] == "" {
+			continue
+		}
+		created, ok := logTime(entry["time"], now)
+		if !ok {
+			created = now
+		}
+		add("log:"+entry[".id"], topics[0], entry["message"], "CRITICAL", entry["topics"], created)
+	}
+	return alarms
+}
+
+// logTime parses the time of a /log entry. RouterOS 7.10 and later print "2006-01-02 15:04:05";
+// older releases print "jan/02 15:04:05" for earlier days and only "15:04:05" for today.
+func logTime(v string, now time.Time) (time.Time, bool) {
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", v, now.Location()); err == nil {
+		return t, true
+	}
+	if t, err := time.ParseInLocation("Jan/02 15:04:05", v, now.Location()); err == nil {
+		t = t.AddDate(now.Year()-t.Year(), 0, 0)
+		if t.After(now) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t, true
+	}
+	if t, err := time.ParseInLocation("15:04:05", v, now.Location()); err == nil {
+		y, m, d := now.Date()
+		return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, now.Location()), true
+	}
+	return time.Time{}, false
+}
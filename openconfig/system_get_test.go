@@ -3,6 +3,7 @@ package openconfig
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSystemGetToMikrotikCmds_TimezoneUTCOffset(t *testing.T) {
@@ -22,3 +23,93 @@ func TestSystemGetToMikrotikCmds_TimezoneName(t *testing.T) {
 		t.Errorf("expected %v, got %v", expected, cmds)
 	}
 }
+
+func TestSystemGetToMikrotikCmds_MemoryAndCPUs(t *testing.T) {
+	cmds := SystemGetToMikrotikCmds(`<system><memory/><cpus/></system>`)
+	expected := []string{"/system/resource/print", "/system/resource/cpu/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemMemoryFromMikrotik(t *testing.T) {
+	mem := SystemMemoryFromMikrotik(map[string]string{"total-memory": "1073741824", "free-memory": "805306368"})
+	if *mem.State.Physical != 1073741824 || *mem.State.Free != 805306368 || *mem.State.Used != 268435456 {
+		t.Errorf("unexpected memory state: physical=%d free=%d used=%d", *mem.State.Physical, *mem.State.Free, *mem.State.Used)
+	}
+}
+
+func TestSystemCPUsFromMikrotik(t *testing.T) {
+	sample := func(cpu0, cpu1 string) []map[string]string {
+		return []map[string]string{{"cpu": "cpu0", "load": cpu0}, {"cpu": "cpu1", "load": cpu1}}
+	}
+
+	cpus := SystemCPUsFromMikrotik([][]map[string]string{sample("12", "3")}, 0)
+	if len(cpus.CPU) != 2 || *cpus.CPU[1].Index != "1" || *cpus.CPU[1].State.Total.Instant != 3 {
+		t.Fatalf("unexpected cpus: %+v", cpus.CPU)
+	}
+	if cpus.CPU[0].State.Total.Avg != nil {
+		t.Errorf("expected no avg for a single sample")
+	}
+
+	cpus = SystemCPUsFromMikrotik([][]map[string]string{sample("10", "0"), sample("40", "5"), sample("20", "1")}, 10*time.Second)
+	total := cpus.CPU[0].State.Total
+	if *total.Instant != 20 || *total.Avg != 23 || *total.Min != 10 || *total.Max != 40 || *total.Interval != 10000000000 {
+		t.Errorf("unexpected cpu0 utilization: instant=%d avg=%d min=%d max=%d interval=%d",
+			*total.Instant, *total.Avg, *total.Min, *total.Max, *total.Interval)
+	}
+}
+
+func TestSystemGetToMikrotikCmds_Processes(t *testing.T) {
+	cmds := SystemGetToMikrotikCmds(`<system><processes/></system>`)
+	expected := []string{"/tool/profile duration=5s cpu=total"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemProcessesFromMikrotik(t *testing.T) {
+	rows := []map[string]string{
+		{"name": "networking", "cpu": "total", "usage": "3%"},
+		{"name": "management", "cpu": "total", "usage": "1%"},
+		{"name": "idle", "cpu": "total", "usage": "96%"},
+		{"name": "networking", "cpu": "total", "usage": "12.6%"},
+	}
+	procs := SystemProcessesFromMikrotik(rows)
+	if len(procs.Process) != 2 {
+		t.Fatalf("expected 2 processes, got %d", len(procs.Process))
+	}
+	networking := procs.Process[0]
+	if *networking.State.Name != "networking" || *networking.State.CPUUtilization != 13 {
+		t.Errorf("unexpected networking state: %s %d", *networking.State.Name, *networking.State.CPUUtilization)
+	}
+	again := SystemProcessesFromMikrotik(rows[:1])
+	if *again.Process[0].PID != *networking.PID || *networking.PID == *procs.Process[1].PID {
+		t.Errorf("expected stable, distinct pids, got %d, %d and %d", *networking.PID, *again.Process[0].PID, *procs.Process[1].PID)
+	}
+}
+
+func TestSystemGetFromMikrotik(t *testing.T) {
+	replies := Replies{
+		"/system/identity/print": {{"name": "router1"}},
+		"/system/clock/print":    {{"time-zone-name": "Europe/London", "gmt-offset": "+01:00"}},
+		"/system/resource/print": {{"total-memory": "1024", "free-memory": "256"}},
+	}
+	sys := SystemGetFromMikrotik(`<system><hostname/><clock><timezone-name/></clock><memory/><dns/></system>`, replies)
+	if sys.Hostname == nil || *sys.Hostname != "router1" {
+		t.Errorf("expected hostname router1, got %v", sys.Hostname)
+	}
+	if sys.Clock == nil || *sys.Clock.TimezoneName != "Europe/London" {
+		t.Errorf("unexpected clock %+v", sys.Clock)
+	}
+	if sys.Memory == nil || *sys.Memory.State.Used != 768 {
+		t.Errorf("unexpected memory %+v", sys.Memory)
+	}
+	// dns was asked for but not printed, so it is left out
+	if sys.DNS != nil {
+		t.Errorf("expected no dns, got %+v", sys.DNS)
+	}
+	if SystemGetFromMikrotik(`<interfaces/>`, replies) != nil {
+		t.Error("expected no system without a system filter")
+	}
+}
@@ -0,0 +1,198 @@
+package openconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// loggingSeverityTopics are the RouterOS severity topics, most severe first
+var loggingSeverityTopics = []string{"critical", "error", "warning", "info", "debug"}
+
+// loggingSeverities maps an OpenConfig syslog severity onto its index in loggingSeverityTopics
+var loggingSeverities = map[string]int{
+	"EMERGENCY":     0,
+	"ALERT":         0,
+	"CRITICAL":      0,
+	"ERROR":         1,
+	"WARNING":       2,
+	"NOTICE":        3,
+	"INFORMATIONAL": 3,
+	"DEBUG":         4,
+}
+
+// loggingFacilityTopics maps OpenConfig syslog facilities onto the RouterOS topic carrying them;
+// ALL matches every topic
+var loggingFacilityTopics = map[string]string{
+	"ALL":           "",
+	"AUTH":          "account",
+	"AUTHPRIV":      "account",
+	"SYSTEM_DAEMON": "system",
+	"CRON":          "script",
+	"NTP":           "ntp",
+}
+
+// loggingConsoleAction is the built-in action printing to the console
+const loggingConsoleAction = "echo"
+
+// handleSystemLogging maps remote-servers onto /system/logging/action entries of target=remote and the
+// console onto the built-in echo action. Each selector becomes one /system/logging rule per RouterOS
+// severity topic at or above its severity, since a rule's topics must all match; the facility adds its
+// topic to each rule. The rules of each configured action are reconciled as a whole.
+func handleSystemLogging(op string, sys *System, state *DeviceState) []string {
+	switch op {
+	case "get":
+		return []string{"/system/logging/action/print", "/system/logging/print"}
+	case "set":
+		var cmds []string
+		if sys.Logging.RemoteServers != nil {
+			for _, srv := range sys.Logging.RemoteServers.RemoteServer {
+				cmds = append(cmds, loggingRemoteServerCmds(&srv, state)...)
+			}
+		}
+		if c := sys.Logging.Console; c != nil {
+			cmds = append(cmds, loggingRuleCmds(loggingConsoleAction, loggingSelectors(c.Selectors, c.Severity), state)...)
+		}
+		return cmds
+	}
+	return nil
+}
+
+func loggingRemoteServerCmds(srv *SystemLoggingRemoteServer, state *DeviceState) []string {
+	host := srv.host()
+	if host == "" {
+		return nil
+	}
+	args := " target=remote remote=" + host
+	if port := srv.port(); port != 0 {
+		args += fmt.Sprintf(" remote-port=%d", port)
+	}
+	if srv.Config != nil && srv.Config.SourceAddress != nil && *srv.Config.SourceAddress != "" {
+		args += " src-address=" + *srv.Config.SourceAddress
+	}
+	name := loggingRemoteActionName(host)
+	cmds := []string{upsertCmd(state, "/system/logging/action", "name", name, args)}
+	return append(cmds, loggingRuleCmds(name, loggingSelectors(srv.Selectors, srv.Severity), state)...)
+}
+
+// loggingSelectors returns the selectors of a destination, treating a bare severity leaf as facility ALL
+func loggingSelectors(selectors *SystemLoggingSelectors, severity *string) []SystemLoggingSelectorConfig {
+	var out []SystemLoggingSelectorConfig
+	if severity != nil && *severity != "" {
+		all := "ALL"
+		out = append(out, SystemLoggingSelectorConfig{Facility: &all, Severity: severity})
+	}
+	if selectors != nil {
+		for _, s := range selectors.Selector {
+			cfg := SystemLoggingSelectorConfig{Facility: s.Facility, Severity: s.Severity}
+			if s.Config != nil {
+				if s.Config.Facility != nil {
+					cfg.Facility = s.Config.Facility
+				}
+				if s.Config.Severity != nil {
+					cfg.Severity = s.Config.Severity
+				}
+			}
+			out = append(out, cfg)
+		}
+	}
+	return out
+}
+
+// loggingRuleCmds makes the /system/logging rules of an action match the selectors: rules whose
+// topics are wanted are kept, missing ones are added and the action's other rules are removed
+func loggingRuleCmds(action string, selectors []SystemLoggingSelectorConfig, state *DeviceState) []string {
+	var cmds []string
+	wanted := map[string]bool{}
+	var order []string
+	for _, sel := range selectors {
+		topics, err := loggingTopics(sel)
+		if err != nil {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: system/logging %s selector: %v", action, err))
+			continue
+		}
+		for _, t := range topics {
+			if !wanted[t] {
+				wanted[t] = true
+				order = append(order, t)
+			}
+		}
+	}
+
+	have := map[string]bool{}
+	for _, row := range state.table("/system/logging") {
+		if row["action"] != action {
+			continue
+		}
+		if wanted[row["topics"]] && !have[row["topics"]] {
+			have[row["topics"]] = true
+			continue
+		}
+		cmds = append(cmds, "/system/logging/remove .id="+row[".id"])
+	}
+	for _, t := range order {
+		if !have[t] {
+			cmds = append(cmds, "/system/logging/add topics="+t+" action="+action)
+		}
+	}
+	return cmds
+}
+
+// loggingTopics expands a selector into the topics of one rule per severity topic, e.g. facility AUTH
+// at WARNING gives "account,critical", "account,error" and "account,warning"
+func loggingTopics(sel SystemLoggingSelectorConfig) ([]string, error) {
+	facility, severity := "ALL", "INFORMATIONAL"
+	if sel.Facility != nil {
+		facility = identity(*sel.Facility)
+	}
+	if sel.Severity != nil {
+		severity = identity(*sel.Severity)
+	}
+	level, ok := loggingSeverities[severity]
+	if !ok {
+		return nil, fmt.Errorf("unknown severity %s", severity)
+	}
+	prefix, ok := loggingFacilityTopics[facility]
+	if !ok {
+		return nil, fmt.Errorf("facility %s has no RouterOS topic", facility)
+	}
+	if prefix != "" {
+		prefix += ","
+	}
+	topics := make([]string, 0, level+1)
+	for _, t := range loggingSeverityTopics[:level+1] {
+		topics = append(topics, prefix+t)
+	}
+	return topics, nil
+}
+
+// loggingRemoteActionName names the /system/logging/action of a remote server, e.g. "syslog-10-0-0-1"
+func loggingRemoteActionName(host string) string {
+	return "syslog-" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, host)
+}
+
+// host returns the remote-server key, falling back to config/host
+func (s *SystemLoggingRemoteServer) host() string {
+	if s.Host != nil {
+		return *s.Host
+	}
+	if s.Config != nil && s.Config.Host != nil {
+		return *s.Config.Host
+	}
+	return ""
+}
+
+// port returns config/remote-port, falling back to the top-level port leaf, or 0 when unset
+func (s *SystemLoggingRemoteServer) port() uint16 {
+	if s.Config != nil && s.Config.RemotePort != nil {
+		return *s.Config.RemotePort
+	}
+	if s.Port != nil {
+		return *s.Port
+	}
+	return 0
+}
@@ -0,0 +1,111 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSystemLogging_MikroTikCmd_Set_NoState(t *testing.T) {
+	host, port, severity := "10.0.0.5", uint16(5514), "WARNING"
+	auth, debug := "oc-log:AUTH", "oc-log:DEBUG"
+	sys := &System{Logging: &SystemLogging{
+		RemoteServers: &SystemLoggingRemoteServers{RemoteServer: []SystemLoggingRemoteServer{{
+			Host:     &host,
+			Port:     &port,
+			Severity: &severity,
+			Selectors: &SystemLoggingSelectors{Selector: []SystemLoggingSelector{
+				{Config: &SystemLoggingSelectorConfig{Facility: &auth, Severity: &debug}},
+			}},
+		}}},
+	}}
+	cmds := handleSystemLogging("set", sys, nil)
+	expected := []string{
+		"/system/logging/action/add name=syslog-10-0-0-5 target=remote remote=10.0.0.5 remote-port=5514",
+		"/system/logging/add topics=critical action=syslog-10-0-0-5",
+		"/system/logging/add topics=error action=syslog-10-0-0-5",
+		"/system/logging/add topics=warning action=syslog-10-0-0-5",
+		"/system/logging/add topics=account,critical action=syslog-10-0-0-5",
+		"/system/logging/add topics=account,error action=syslog-10-0-0-5",
+		"/system/logging/add topics=account,warning action=syslog-10-0-0-5",
+		"/system/logging/add topics=account,info action=syslog-10-0-0-5",
+		"/system/logging/add topics=account,debug action=syslog-10-0-0-5",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemLogging_MikroTikCmd_Set_ConsoleReconcile(t *testing.T) {
+	severity := "ERROR"
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/system/logging": {
+			{".id": "*1", "topics": "critical", "action": "echo"},
+			{".id": "*2", "topics": "info", "action": "echo"},
+			{".id": "*3", "topics": "info", "action": "memory"},
+		},
+	}}
+	sys := &System{Logging: &SystemLogging{Console: &SystemLoggingConsole{Severity: &severity}}}
+	cmds := handleSystemLogging("set", sys, state)
+	expected := []string{
+		"/system/logging/remove .id=*2",
+		"/system/logging/add topics=error action=echo",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemLogging_MikroTikCmd_Set_UnsupportedFacility(t *testing.T) {
+	facility, severity := "LOCAL7", "INFORMATIONAL"
+	sys := &System{Logging: &SystemLogging{Console: &SystemLoggingConsole{Selectors: &SystemLoggingSelectors{
+		Selector: []SystemLoggingSelector{{Facility: &facility, Severity: &severity}},
+	}}}}
+	cmds := handleSystemLogging("set", sys, nil)
+	expected := []string{"UNSUPPORTED: system/logging echo selector: facility LOCAL7 has no RouterOS topic"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemLoggingFromMikrotik(t *testing.T) {
+	actions := []map[string]string{
+		{"name": "memory", "target": "memory"},
+		{"name": "syslog-10-0-0-5", "target": "remote", "remote": "10.0.0.5", "remote-port": "514"},
+	}
+	rules := []map[string]string{
+		{"topics": "critical", "action": "echo"},
+		{"topics": "critical", "action": "syslog-10-0-0-5"},
+		{"topics": "error", "action": "syslog-10-0-0-5"},
+		{"topics": "warning", "action": "syslog-10-0-0-5"},
+		{"topics": "account,info", "action": "syslog-10-0-0-5"},
+		{"topics": "hotspot,info", "action": "syslog-10-0-0-5"},
+	}
+	logging := SystemLoggingFromMikrotik(actions, rules)
+	console := logging.Console.Selectors.Selector
+	if len(console) != 1 || *console[0].Facility != "ALL" || *console[0].Severity != "CRITICAL" {
+		t.Errorf("unexpected console selectors %+v", console)
+	}
+	if len(logging.RemoteServers.RemoteServer) != 1 {
+		t.Fatalf("expected 1 remote server, got %d", len(logging.RemoteServers.RemoteServer))
+	}
+	srv := logging.RemoteServers.RemoteServer[0]
+	if *srv.Config.Host != "10.0.0.5" || *srv.Config.RemotePort != 514 {
+		t.Errorf("unexpected remote server config %+v", srv.Config)
+	}
+	var got []string
+	for _, s := range srv.Selectors.Selector {
+		got = append(got, *s.Facility+"/"+*s.Severity)
+	}
+	expected := []string{"ALL/WARNING", "AUTH/INFORMATIONAL"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestSystemGetToMikrotikCmds_Logging(t *testing.T) {
+	cmds := SystemGetToMikrotikCmds(`<system><logging/></system>`)
+	expected := []string{"/system/logging/action/print", "/system/logging/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
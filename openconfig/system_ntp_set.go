@@ -0,0 +1,194 @@
+package openconfig
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// handleSystemNTP maps ntp onto the RouterOS NTP client, choosing the model by version. RouterOS 7
+// keeps a /system/ntp/client/servers list with per-server iburst, reconciled by address against
+// device state so servers no longer listed are removed (without state servers are only added), and
+// the ntp-server vendor container maps onto /system/ntp/server. RouterOS 6 only has
+// primary-ntp/secondary-ntp addresses and server-dns-names on /system/ntp/client. Options RouterOS
+// has no equivalent for (port other than 123, version other than 4, PEER associations, prefer,
+// source addresses) are rejected.
+func handleSystemNTP(op string, sys *System, state *DeviceState) []string {
+	switch op {
+	case "get":
+		return []string{"/system/ntp/client/print", "/system/ntp/client/monitor once="}
+	case "set":
+		if state.majorVersion() < 7 {
+			return ntpV6Cmds(sys.NTP)
+		}
+		return ntpV7Cmds(sys.NTP, state)
+	}
+	return nil
+}
+
+func ntpV7Cmds(ntp *SystemNTP, state *DeviceState) []string {
+	var cmds []string
+	if enabled := ntp.enabled(); enabled != nil {
+		cmds = append(cmds, "/system/ntp/client/set enabled="+yesNo(*enabled))
+	}
+	cmds = append(cmds, ntpUnsupportedCmds(ntp)...)
+	desired := map[string]bool{}
+	for _, s := range ntp.servers() {
+		addr := s.address()
+		desired[addr] = true
+		args := ""
+		if s.Config != nil && s.Config.Iburst != nil {
+			args += " iburst=" + yesNo(*s.Config.Iburst)
+		}
+		if row := state.find("/system/ntp/client/servers", "address", addr); row != nil {
+			if args != "" {
+				cmds = append(cmds, "/system/ntp/client/servers/set .id="+row[".id"]+args)
+			}
+			continue
+		}
+		cmds = append(cmds, "/system/ntp/client/servers/add address="+addr+args)
+	}
+	if ntp.Servers != nil {
+		for _, row := range state.table("/system/ntp/client/servers") {
+			if !desired[row["address"]] {
+				cmds = append(cmds, "/system/ntp/client/servers/remove .id="+row[".id"])
+			}
+		}
+	}
+	if ntp.NTPServer != nil && ntp.NTPServer.Config != nil {
+		cfg := ntp.NTPServer.Config
+		args := ""
+		if cfg.Enabled != nil {
+			args += " enabled=" + yesNo(*cfg.Enabled)
+		}
+		if cfg.Broadcast != nil {
+			args += " broadcast=" + yesNo(*cfg.Broadcast)
+		}
+		if cfg.Manycast != nil {
+			args += " manycast=" + yesNo(*cfg.Manycast)
+		}
+		if cfg.BroadcastAddresses != nil {
+			args += " broadcast-addresses=" + quote(strings.Join(cfg.BroadcastAddresses, ","))
+		}
+		if args != "" {
+			cmds = append(cmds, "/system/ntp/server/set"+args)
+		}
+	}
+	return cmds
+}
+
+func ntpV6Cmds(ntp *SystemNTP) []string {
+	var cmds []string
+	args := ""
+	if enabled := ntp.enabled(); enabled != nil {
+		args += " enabled=" + yesNo(*enabled)
+	}
+	cmds = append(cmds, ntpUnsupportedCmds(ntp)...)
+	if ntp.Servers != nil {
+		var ips, names []string
+		for _, s := range ntp.servers() {
+			if s.Config != nil && s.Config.Iburst != nil {
+				cmds = append(cmds, "UNSUPPORTED: system/ntp server "+s.address()+" iburst requires RouterOS 7")
+			}
+			if net.ParseIP(s.address()) != nil {
+				ips = append(ips, s.address())
+			} else {
+				names = append(names, s.address())
+			}
+		}
+		if len(ips) > 2 {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: system/ntp RouterOS 6 takes two server addresses, ignoring %s", strings.Join(ips[2:], ",")))
+			ips = ips[:2]
+		}
+		primary, secondary := "0.0.0.0", "0.0.0.0"
+		if len(ips) > 0 {
+			primary = ips[0]
+		}
+		if len(ips) > 1 {
+			secondary = ips[1]
+		}
+		args += " primary-ntp=" + primary + " secondary-ntp=" + secondary + " server-dns-names=" + quote(strings.Join(names, ","))
+	}
+	if args != "" {
+		cmds = append(cmds, "/system/ntp/client/set"+args)
+	}
+	if ntp.NTPServer != nil {
+		cmds = append(cmds, "UNSUPPORTED: system/ntp ntp-server requires RouterOS 7")
+	}
+	return cmds
+}
+
+// ntpUnsupportedCmds reports the NTP options RouterOS has no equivalent for
+func ntpUnsupportedCmds(ntp *SystemNTP) []string {
+	var cmds []string
+	if ntp.Config != nil && ntp.Config.NTPSourceAddress != nil {
+		cmds = append(cmds, "UNSUPPORTED: system/ntp ntp-source-address, the RouterOS client has no source address")
+	}
+	for _, s := range ntp.servers() {
+		addr := s.address()
+		if port := s.port(); port != 0 && port != 123 {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: system/ntp server %s port %d, RouterOS only queries port 123", addr, port))
+		}
+		cfg := s.Config
+		if cfg == nil {
+			continue
+		}
+		if cfg.Version != nil && *cfg.Version != 4 {
+			cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: system/ntp server %s version %d, RouterOS speaks NTPv4", addr, *cfg.Version))
+		}
+		if cfg.AssociationType != nil && identity(*cfg.AssociationType) == "PEER" {
+			cmds = append(cmds, "UNSUPPORTED: system/ntp server "+addr+" association-type PEER")
+		}
+		if cfg.Prefer != nil && *cfg.Prefer {
+			cmds = append(cmds, "UNSUPPORTED: system/ntp server "+addr+" prefer")
+		}
+		if cfg.SourceAddress != nil {
+			cmds = append(cmds, "UNSUPPORTED: system/ntp server "+addr+" source-address")
+		}
+	}
+	return cmds
+}
+
+// enabled returns config/enabled, falling back to the top-level enabled leaf
+func (n *SystemNTP) enabled() *bool {
+	if n.Config != nil && n.Config.Enabled != nil {
+		return n.Config.Enabled
+	}
+	return n.Enabled
+}
+
+// servers returns the configured servers that have an address
+func (n *SystemNTP) servers() []SystemNTPServer {
+	if n.Servers == nil {
+		return nil
+	}
+	var out []SystemNTPServer
+	for _, s := range n.Servers.Server {
+		if s.address() != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// address returns the server key, falling back to config/address
+func (s *SystemNTPServer) address() string {
+	if s.Address != nil {
+		return *s.Address
+	}
+	if s.Config != nil && s.Config.Address != nil {
+		return *s.Config.Address
+	}
+	return ""
+}
+
+// port returns config/port, falling back to the top-level port leaf, or 0 when unset
+func (s *SystemNTPServer) port() uint16 {
+	if s.Config != nil && s.Config.Port != nil {
+		return *s.Config.Port
+	}
+	if s.Port != nil {
+		return *s.Port
+	}
+	return 0
+}
@@ -10,8 +10,8 @@ func TestSystemNTP_MikroTikCmd_Set_Enabled(t *testing.T) {
 	sys := &System{
 		NTP: &SystemNTP{Enabled: &enabled},
 	}
-	cmds := handleSystemNTP("set", sys)
-	expected := []string{"/system/ntp/client/set", "enabled=yes"}
+	cmds := handleSystemNTP("set", sys, nil)
+	expected := []string{"/system/ntp/client/set enabled=yes"}
 	if !reflect.DeepEqual(cmds, expected) {
 		t.Errorf("expected %v, got %v", expected, cmds)
 	}
@@ -22,8 +22,8 @@ func TestSystemNTP_MikroTikCmd_Set_Disabled(t *testing.T) {
 	sys := &System{
 		NTP: &SystemNTP{Enabled: &enabled},
 	}
-	cmds := handleSystemNTP("set", sys)
-	expected := []string{"/system/ntp/client/set", "enabled=no"}
+	cmds := handleSystemNTP("set", sys, nil)
+	expected := []string{"/system/ntp/client/set enabled=no"}
 	if !reflect.DeepEqual(cmds, expected) {
 		t.Errorf("expected %v, got %v", expected, cmds)
 	}
@@ -42,8 +42,11 @@ func TestSystemNTP_MikroTikCmd_Set_Servers(t *testing.T) {
 			},
 		},
 	}
-	cmds := handleSystemNTP("set", sys)
-	expected := []string{"/system/ntp/client/servers/add", "address=1.2.3.4", "/system/ntp/client/servers/add", "address=5.6.7.8"}
+	cmds := handleSystemNTP("set", sys, nil)
+	expected := []string{
+		"/system/ntp/client/servers/add address=1.2.3.4",
+		"/system/ntp/client/servers/add address=5.6.7.8",
+	}
 	if !reflect.DeepEqual(cmds, expected) {
 		t.Errorf("expected %v, got %v", expected, cmds)
 	}
@@ -51,9 +54,92 @@ func TestSystemNTP_MikroTikCmd_Set_Servers(t *testing.T) {
 
 func TestSystemNTP_MikroTikCmd_Get(t *testing.T) {
 	sys := &System{NTP: &SystemNTP{}}
-	cmds := handleSystemNTP("get", sys)
-	expected := []string{"/system/ntp/client/print"}
+	cmds := handleSystemNTP("get", sys, nil)
+	expected := []string{"/system/ntp/client/print", "/system/ntp/client/monitor once="}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemNTP_MikroTikCmd_Set_V7Options(t *testing.T) {
+	addr1, addr2 := "time.cloudflare.com", "10.0.0.1"
+	iburst, on, off := true, true, false
+	sys := &System{NTP: &SystemNTP{
+		Config: &SystemNTPConfig{Enabled: &on},
+		Servers: &SystemNTPServers{Server: []SystemNTPServer{
+			{Address: &addr1, Config: &SystemNTPServerConfig{Iburst: &iburst}},
+			{Address: &addr2, Config: &SystemNTPServerConfig{Iburst: &off}},
+		}},
+		NTPServer: &SystemNTPServerMode{Config: &SystemNTPServerModeConfig{Enabled: &on, Manycast: &on, Broadcast: &off}},
+	}}
+	state := &DeviceState{Version: "7.15.3 (stable)", Tables: map[string][]map[string]string{
+		"/system/ntp/client/servers": {{".id": "*1", "address": "10.0.0.1"}, {".id": "*2", "address": "10.0.0.9"}},
+	}}
+	cmds := handleSystemNTP("set", sys, state)
+	expected := []string{
+		"/system/ntp/client/set enabled=yes",
+		"/system/ntp/client/servers/add address=time.cloudflare.com iburst=yes",
+		"/system/ntp/client/servers/set .id=*1 iburst=no",
+		"/system/ntp/client/servers/remove .id=*2",
+		"/system/ntp/server/set enabled=yes broadcast=no manycast=yes",
+	}
 	if !reflect.DeepEqual(cmds, expected) {
 		t.Errorf("expected %v, got %v", expected, cmds)
 	}
 }
+
+func TestSystemNTP_MikroTikCmd_Set_V6(t *testing.T) {
+	enabled := true
+	addrs := []string{"10.0.0.1", "pool.ntp.org", "10.0.0.2", "10.0.0.3"}
+	sys := &System{NTP: &SystemNTP{Enabled: &enabled, Servers: &SystemNTPServers{}}}
+	for n := range addrs {
+		sys.NTP.Servers.Server = append(sys.NTP.Servers.Server, SystemNTPServer{Address: &addrs[n]})
+	}
+	cmds := handleSystemNTP("set", sys, &DeviceState{Version: "6.49.10 (long-term)"})
+	expected := []string{
+		"UNSUPPORTED: system/ntp RouterOS 6 takes two server addresses, ignoring 10.0.0.3",
+		`/system/ntp/client/set enabled=yes primary-ntp=10.0.0.1 secondary-ntp=10.0.0.2 server-dns-names="pool.ntp.org"`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemNTP_MikroTikCmd_Set_Unsupported(t *testing.T) {
+	addr, peer, prefer := "10.0.0.1", "PEER", true
+	port, version := uint16(1123), uint8(3)
+	sys := &System{NTP: &SystemNTP{Servers: &SystemNTPServers{Server: []SystemNTPServer{
+		{Address: &addr, Port: &port, Config: &SystemNTPServerConfig{Version: &version, AssociationType: &peer, Prefer: &prefer}},
+	}}}}
+	cmds := handleSystemNTP("set", sys, nil)
+	expected := []string{
+		"UNSUPPORTED: system/ntp server 10.0.0.1 port 1123, RouterOS only queries port 123",
+		"UNSUPPORTED: system/ntp server 10.0.0.1 version 3, RouterOS speaks NTPv4",
+		"UNSUPPORTED: system/ntp server 10.0.0.1 association-type PEER",
+		"UNSUPPORTED: system/ntp server 10.0.0.1 prefer",
+		"/system/ntp/client/servers/add address=10.0.0.1",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemNTPStateFromMikrotik(t *testing.T) {
+	servers := SystemNTPStateFromMikrotik(map[string]string{
+		"status": "synchronized", "synced-server": "10.0.0.1", "synced-stratum": "2", "system-offset": "-1.6",
+	})
+	if len(servers.Server) != 1 {
+		t.Fatalf("expected 1 server, got %d", len(servers.Server))
+	}
+	st := servers.Server[0].State
+	if *st.Address != "10.0.0.1" || *st.Stratum != 2 || *st.Offset != -2 || *st.Status != "synchronized" {
+		t.Errorf("unexpected state %+v", st)
+	}
+	v6 := SystemNTPStateFromMikrotik(map[string]string{"status": "synchronized", "last-update-from": "10.0.0.2", "last-adjustment": "12ms400us"})
+	if st := v6.Server[0].State; *st.Address != "10.0.0.2" || *st.Offset != 12 || st.Stratum != nil {
+		t.Errorf("unexpected RouterOS 6 state %+v", st)
+	}
+	if empty := SystemNTPStateFromMikrotik(map[string]string{"status": "waiting"}); len(empty.Server) != 0 {
+		t.Errorf("expected no server while unsynchronized, got %+v", empty.Server)
+	}
+}
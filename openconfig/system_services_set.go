@@ -0,0 +1,201 @@
+package openconfig
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// managementServices are the /ip/service entries RouterOS always has
+var managementServices = map[string]bool{
+	"api": true, "api-ssl": true, "winbox": true, "www": true, "www-ssl": true, "ftp": true, "telnet": true, "ssh": true,
+}
+
+// handleSystemSSHServer maps ssh-server/config onto the /ip/service ssh entry (enable, port and the
+// address allow-list) and /ip/ssh (strong-crypto, host-key-size). RouterOS only speaks SSHv2 and has
+// no idle timeout or connection rate limit for the SSH server, so those leaves are rejected.
+func handleSystemSSHServer(op string, sys *System, state *DeviceState) []string {
+	switch op {
+	case "get":
+		return []string{"/ip/service/print", "/ip/ssh/print"}
+	case "set":
+		cfg := sys.SSHServer.Config
+		if cfg == nil {
+			return nil
+		}
+		var cmds []string
+		if cfg.ProtocolVersion != nil && identity(*cfg.ProtocolVersion) != "V2" {
+			cmds = append(cmds, "UNSUPPORTED: system/ssh-server protocol-version "+identity(*cfg.ProtocolVersion)+", RouterOS only supports SSHv2")
+		}
+		if cfg.Timeout != nil {
+			cmds = append(cmds, "UNSUPPORTED: system/ssh-server timeout is not configurable on RouterOS")
+		}
+		if cfg.RateLimit != nil {
+			cmds = append(cmds, "UNSUPPORTED: system/ssh-server rate-limit is not configurable on RouterOS")
+		}
+		if cmd := serviceSetCmd(state, "ssh", cfg.Enable, cfg.Port, cfg.Address); cmd != "" {
+			cmds = append(cmds, cmd)
+		}
+		args := ""
+		if cfg.StrongCrypto != nil {
+			args += " strong-crypto=" + yesNo(*cfg.StrongCrypto)
+		}
+		if cfg.HostKeySize != nil {
+			args += fmt.Sprintf(" host-key-size=%d", *cfg.HostKeySize)
+		}
+		if args != "" {
+			cmds = append(cmds, "/ip/ssh/set"+args)
+		}
+		return cmds
+	}
+	return nil
+}
+
+// handleSystemTelnetServer maps telnet-server/config/enable onto the /ip/service telnet entry
+func handleSystemTelnetServer(op string, sys *System, state *DeviceState) []string {
+	switch op {
+	case "get":
+		return []string{"/ip/service/print"}
+	case "set":
+		if cfg := sys.TelnetServer.Config; cfg != nil {
+			if cmd := serviceSetCmd(state, "telnet", cfg.Enable, nil, nil); cmd != "" {
+				return []string{cmd}
+			}
+		}
+	}
+	return nil
+}
+
+// defaultServicePorts are the ports the API services listen on until moved
+var defaultServicePorts = map[string]string{"api": "8728", "api-ssl": "8729"}
+
+// CheckManagementLockout refuses a system edit that would disable, move or restrict the /ip/service
+// the translator is connected over, which would cut the session mid-push and leave the device
+// unreachable through the API. A port is compared with the one read into state (or the service
+// default), and an address allow-list must cover state.ManagementSource; with no known source any
+// non-empty allow-list is refused. state.AllowManagementLockout overrides the check.
+func CheckManagementLockout(sys *System, state *DeviceState) error {
+	if sys == nil || sys.Services == nil || (state != nil && state.AllowManagementLockout) {
+		return nil
+	}
+	for _, protected := range state.managementServices() {
+		for _, svc := range sys.Services.Service {
+			if svc.name() != protected || svc.Config == nil {
+				continue
+			}
+			cfg := svc.Config
+			if cfg.Enabled != nil && !*cfg.Enabled {
+				return fmt.Errorf("refusing to disable %s, the service the translator is connected over; set AllowManagementLockout to override", protected)
+			}
+			if cfg.Port != nil {
+				current := defaultServicePorts[protected]
+				if row := state.find("/ip/service", "name", protected); row != nil && row["port"] != "" {
+					current = row["port"]
+				}
+				if port := strconv.Itoa(int(*cfg.Port)); port != current {
+					return fmt.Errorf("refusing to move %s from port %s to %s, the service the translator is connected over; set AllowManagementLockout to override", protected, current, port)
+				}
+			}
+			if !serviceAddressAllows(cfg.Address, state) {
+				return fmt.Errorf("refusing to restrict %s to %s, which does not cover the translator's address; set AllowManagementLockout to override", protected, strings.Join(cfg.Address, ","))
+			}
+		}
+	}
+	return nil
+}
+
+// serviceAddressAllows reports whether an /ip/service address allow-list admits the management
+// source. An empty list (or a lone "") admits everyone; entries are prefixes or bare addresses.
+func serviceAddressAllows(address []string, state *DeviceState) bool {
+	var prefixes []string
+	for _, a := range address {
+		for _, p := range strings.Split(a, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				prefixes = append(prefixes, p)
+			}
+		}
+	}
+	if len(prefixes) == 0 {
+		return true
+	}
+	if state == nil {
+		return false
+	}
+	source := net.ParseIP(state.ManagementSource)
+	if source == nil {
+		return false
+	}
+	for _, p := range prefixes {
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.Equal(source) {
+				return true
+			}
+			continue
+		}
+		if _, prefix, err := net.ParseCIDR(p); err == nil && prefix.Contains(source) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleSystemServices maps the services vendor augmentation onto /ip/service, so every
+// management plane can be enabled, moved or restricted from one payload.
+func handleSystemServices(op string, sys *System, state *DeviceState) []string {
+	switch op {
+	case "get":
+		return []string{"/ip/service/print"}
+	case "set":
+		var cmds []string
+		for _, svc := range sys.Services.Service {
+			name := svc.name()
+			if name == "" || svc.Config == nil {
+				continue
+			}
+			if !managementServices[name] {
+				cmds = append(cmds, "UNSUPPORTED: system/services service "+name+" is not a RouterOS /ip/service")
+				continue
+			}
+			if cmd := serviceSetCmd(state, name, svc.Config.Enabled, svc.Config.Port, svc.Config.Address); cmd != "" {
+				cmds = append(cmds, cmd)
+			}
+		}
+		return cmds
+	}
+	return nil
+}
+
+// serviceSetCmd renders an /ip/service set for the named service, or "" when nothing is configured.
+// The entry is addressed by its .id from device state, else by name, which /ip/service accepts as
+// numbers. An address list entry of "" clears the allow-list.
+func serviceSetCmd(state *DeviceState, name string, enabled *bool, port *uint16, address []string) string {
+	args := ""
+	if enabled != nil {
+		args += " disabled=" + yesNo(!*enabled)
+	}
+	if port != nil {
+		args += fmt.Sprintf(" port=%d", *port)
+	}
+	if address != nil {
+		args += " address=" + quote(strings.Join(address, ","))
+	}
+	if args == "" {
+		return ""
+	}
+	if row := state.find("/ip/service", "name", name); row != nil {
+		return "/ip/service/set .id=" + row[".id"] + args
+	}
+	return "/ip/service/set numbers=" + name + args
+}
+
+// name returns the service key, falling back to config/name
+func (s *SystemService) name() string {
+	if s.Name != nil {
+		return *s.Name
+	}
+	if s.Config != nil && s.Config.Name != nil {
+		return *s.Config.Name
+	}
+	return ""
+}
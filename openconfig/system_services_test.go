@@ -0,0 +1,173 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSystemSSHServer_MikroTikCmd_Set(t *testing.T) {
+	enable, strong := true, true
+	port, keySize := uint16(2222), uint16(4096)
+	sys := &System{SSHServer: &SystemSSHServer{Config: &SystemSSHServerConfig{
+		Enable:       &enable,
+		Port:         &port,
+		Address:      []string{"10.0.0.0/8", "192.168.88.0/24"},
+		StrongCrypto: &strong,
+		HostKeySize:  &keySize,
+	}}}
+	cmds := handleSystemSSHServer("set", sys, nil)
+	expected := []string{
+		`/ip/service/set numbers=ssh disabled=no port=2222 address="10.0.0.0/8,192.168.88.0/24"`,
+		"/ip/ssh/set strong-crypto=yes host-key-size=4096",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+
+	// With device state the entry is set by .id
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/ip/service": {{".id": "*3", "name": "telnet"}, {".id": "*4", "name": "ssh", "port": "22"}},
+	}}
+	cmds = handleSystemSSHServer("set", sys, state)
+	expected[0] = `/ip/service/set .id=*4 disabled=no port=2222 address="10.0.0.0/8,192.168.88.0/24"`
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemSSHServer_MikroTikCmd_Set_Unsupported(t *testing.T) {
+	version, timeout, rate := "oc-sys:V1_V2", uint16(300), uint16(10)
+	sys := &System{SSHServer: &SystemSSHServer{Config: &SystemSSHServerConfig{ProtocolVersion: &version, Timeout: &timeout, RateLimit: &rate}}}
+	cmds := handleSystemSSHServer("set", sys, nil)
+	expected := []string{
+		"UNSUPPORTED: system/ssh-server protocol-version V1_V2, RouterOS only supports SSHv2",
+		"UNSUPPORTED: system/ssh-server timeout is not configurable on RouterOS",
+		"UNSUPPORTED: system/ssh-server rate-limit is not configurable on RouterOS",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemServices_MikroTikCmd_Set(t *testing.T) {
+	off, on := false, true
+	port := uint16(8291)
+	www, winbox, gopher := "www", "winbox", "gopher"
+	sys := &System{Services: &SystemServices{Service: []SystemService{
+		{Name: &www, Config: &SystemServiceConfig{Enabled: &off}},
+		{Name: &winbox, Config: &SystemServiceConfig{Enabled: &on, Port: &port, Address: []string{"10.0.0.0/8"}}},
+		{Name: &gopher, Config: &SystemServiceConfig{Enabled: &on}},
+	}}}
+	cmds := handleSystemServices("set", sys, nil)
+	expected := []string{
+		"/ip/service/set numbers=www disabled=yes",
+		`/ip/service/set numbers=winbox disabled=no port=8291 address="10.0.0.0/8"`,
+		"UNSUPPORTED: system/services service gopher is not a RouterOS /ip/service",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemSSHServerFromMikrotik(t *testing.T) {
+	services := []map[string]string{
+		{"name": "telnet", "port": "23", "disabled": "true"},
+		{"name": "ssh", "port": "22", "address": "10.0.0.0/8,192.168.88.0/24", "disabled": "false"},
+	}
+	ssh := SystemSSHServerFromMikrotik(services, map[string]string{"strong-crypto": "yes", "host-key-size": "2048"})
+	cfg := ssh.Config
+	if !*cfg.Enable || *cfg.Port != 22 || len(cfg.Address) != 2 || !*cfg.StrongCrypto || *cfg.HostKeySize != 2048 || *cfg.ProtocolVersion != "V2" {
+		t.Errorf("unexpected ssh-server config %+v", cfg)
+	}
+	all := SystemServicesFromMikrotik(services)
+	if len(all.Service) != 2 || *all.Service[0].Config.Enabled || all.Service[0].Config.Address != nil {
+		t.Errorf("unexpected services %+v", all.Service)
+	}
+}
+
+func TestSystemGetFromMikrotik_ServicesByName(t *testing.T) {
+	replies := Replies{"/ip/service/print": {
+		{"name": "ftp", "port": "2121", "disabled": "true"},
+		{"name": "ssh", "port": "22", "disabled": "false"},
+	}}
+	sys := SystemGetFromMikrotik(`<system><services><service><name>ftp</name></service></services></system>`, replies)
+	if len(sys.Services.Service) != 1 || sys.Services.Service[0].name() != "ftp" || *sys.Services.Service[0].Config.Port != 2121 {
+		t.Errorf("expected only ftp, got %+v", sys.Services.Service)
+	}
+	if sys = SystemGetFromMikrotik(`<system><services/></system>`, replies); len(sys.Services.Service) != 2 {
+		t.Errorf("expected every service, got %+v", sys.Services.Service)
+	}
+}
+
+func TestSystemGetToMikrotikCmds_SSHServer(t *testing.T) {
+	cmds := SystemGetToMikrotikCmds(`<system><ssh-server/><services/></system>`)
+	expected := []string{"/ip/service/print", "/ip/ssh/print"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestSystemTelnetServer_MikroTikCmd_Set(t *testing.T) {
+	off := false
+	sys := &System{TelnetServer: &SystemTelnetServer{Config: &SystemTelnetServerConfig{Enable: &off}}}
+	cmds := handleSystemTelnetServer("set", sys, nil)
+	expected := []string{"/ip/service/set numbers=telnet disabled=yes"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+	telnet := SystemTelnetServerFromMikrotik([]map[string]string{{"name": "telnet", "disabled": "true"}})
+	if telnet.Config.Enable == nil || *telnet.Config.Enable {
+		t.Errorf("expected telnet disabled, got %+v", telnet.Config)
+	}
+}
+
+func TestCheckManagementLockout(t *testing.T) {
+	off := false
+	disable := func(names ...string) *System {
+		sys := &System{Services: &SystemServices{}}
+		for _, n := range names {
+			n := n
+			sys.Services.Service = append(sys.Services.Service, SystemService{Name: &n, Config: &SystemServiceConfig{Enabled: &off}})
+		}
+		return sys
+	}
+	service := func(name string, cfg SystemServiceConfig) *System {
+		return &System{Services: &SystemServices{Service: []SystemService{{Name: &name, Config: &cfg}}}}
+	}
+	port8728, port9000 := uint16(8728), uint16(9000)
+	tests := []struct {
+		name    string
+		sys     *System
+		state   *DeviceState
+		refused bool
+	}{
+		{"unknown connection protects api", disable("api"), nil, true},
+		{"unknown connection protects api-ssl", disable("api-ssl"), nil, true},
+		{"other services may be disabled", disable("telnet", "www", "ftp"), nil, false},
+		{"only the connected service is protected", disable("api"), &DeviceState{ManagementService: "api-ssl"}, false},
+		{"connected service", disable("api-ssl"), &DeviceState{ManagementService: "api-ssl"}, true},
+		{"override", disable("api", "api-ssl"), &DeviceState{AllowManagementLockout: true}, false},
+		{"no system", nil, nil, false},
+		{"default port kept", service("api", SystemServiceConfig{Port: &port8728}), nil, false},
+		{"port moved", service("api", SystemServiceConfig{Port: &port9000}), nil, true},
+		{"port matches device", service("api", SystemServiceConfig{Port: &port9000}), &DeviceState{Tables: map[string][]map[string]string{
+			"/ip/service": {{".id": "*1", "name": "api", "port": "9000"}},
+		}}, false},
+		{"port moved off device value", service("api", SystemServiceConfig{Port: &port8728}), &DeviceState{Tables: map[string][]map[string]string{
+			"/ip/service": {{".id": "*1", "name": "api", "port": "9000"}},
+		}}, true},
+		{"other service may move", service("ssh", SystemServiceConfig{Port: &port9000}), nil, false},
+		{"allow-list with unknown source", service("api", SystemServiceConfig{Address: []string{"192.0.2.0/24"}}), nil, true},
+		{"allow-list covers source", service("api", SystemServiceConfig{Address: []string{"198.51.100.0/24", "192.0.2.0/24"}}), &DeviceState{ManagementSource: "192.0.2.10"}, false},
+		{"allow-list names source", service("api", SystemServiceConfig{Address: []string{"192.0.2.10"}}), &DeviceState{ManagementSource: "192.0.2.10"}, false},
+		{"allow-list misses source", service("api", SystemServiceConfig{Address: []string{"198.51.100.0/24"}}), &DeviceState{ManagementSource: "192.0.2.10"}, true},
+		{"allow-list cleared", service("api", SystemServiceConfig{Address: []string{""}}), nil, false},
+		{"allow-list override", service("api", SystemServiceConfig{Address: []string{"198.51.100.0/24"}, Port: &port9000}), &DeviceState{AllowManagementLockout: true}, false},
+	}
+	for _, tt := range tests {
+		err := CheckManagementLockout(tt.sys, tt.state)
+		if (err != nil) != tt.refused {
+			t.Errorf("%s: expected refused=%v, got %v", tt.name, tt.refused, err)
+		}
+	}
+}
@@ -1,42 +1,76 @@
 package openconfig
 
-type SystemFeatureHandler func(op string, sys *System) []string
+import "strings"
+
+// SystemFeatureHandler translates one system feature. state is the device state to reconcile
+// against and may be nil.
+type SystemFeatureHandler func(op string, sys *System, state *DeviceState) []string
 
 var systemFeatureHandlers = map[string]SystemFeatureHandler{
 	"hostname": handleSystemHostname,
 	"clock":    handleSystemClock,
 	"ntp":      handleSystemNTP,
 	"dns":      handleSystemDNS,
+	"aaa":      handleSystemAAA,
+	"logging":  handleSystemLogging,
+	"ssh":      handleSystemSSHServer,
+	"services": handleSystemServices,
+	"telnet":   handleSystemTelnetServer,
+	"banner":   handleSystemBanner,
 }
 
 // SystemToMikrotikCmdsRegistry dispatches to feature handlers for get/set
-func SystemToMikrotikCmdsRegistry(op string, sys *System) []string {
+func SystemToMikrotikCmdsRegistry(op string, sys *System, state *DeviceState) []string {
 	var cmds []string
 	for feature, handler := range systemFeatureHandlers {
 		// Only call handler if the feature is present in the struct
 		switch feature {
 		case "hostname":
 			if sys != nil && sys.Hostname != nil {
-				cmds = append(cmds, handler(op, sys)...)
+				cmds = append(cmds, handler(op, sys, state)...)
 			}
 		case "clock":
 			if sys != nil && sys.Clock != nil {
-				cmds = append(cmds, handler(op, sys)...)
+				cmds = append(cmds, handler(op, sys, state)...)
 			}
 		case "ntp":
 			if sys != nil && sys.NTP != nil {
-				cmds = append(cmds, handler(op, sys)...)
+				cmds = append(cmds, handler(op, sys, state)...)
 			}
 		case "dns":
 			if sys != nil && sys.DNS != nil {
-				cmds = append(cmds, handler(op, sys)...)
+				cmds = append(cmds, handler(op, sys, state)...)
+			}
+		case "aaa":
+			if sys != nil && sys.AAA != nil {
+				cmds = append(cmds, handler(op, sys, state)...)
+			}
+		case "logging":
+			if sys != nil && sys.Logging != nil {
+				cmds = append(cmds, handler(op, sys, state)...)
+			}
+		case "ssh":
+			if sys != nil && sys.SSHServer != nil {
+				cmds = append(cmds, handler(op, sys, state)...)
+			}
+		case "services":
+			if sys != nil && sys.Services != nil {
+				cmds = append(cmds, handler(op, sys, state)...)
+			}
+		case "telnet":
+			if sys != nil && sys.TelnetServer != nil {
+				cmds = append(cmds, handler(op, sys, state)...)
+			}
+		case "banner":
+			if sys != nil && sys.Config != nil && (sys.Config.LoginBanner != nil || sys.Config.MotdBanner != nil) {
+				cmds = append(cmds, handler(op, sys, state)...)
 			}
 		}
 	}
 	return cmds
 }
 
-func handleSystemHostname(op string, sys *System) []string {
+func handleSystemHostname(op string, sys *System, state *DeviceState) []string {
 	switch op {
 	case "get":
 		return []string{"/system/identity/print"}
@@ -48,7 +82,7 @@ func handleSystemHostname(op string, sys *System) []string {
 	return nil
 }
 
-func handleSystemClock(op string, sys *System) []string {
+func handleSystemClock(op string, sys *System, state *DeviceState) []string {
 	switch op {
 	case "set":
 		if sys.Clock != nil {
@@ -65,42 +99,23 @@ func handleSystemClock(op string, sys *System) []string {
 	return nil
 }
 
-func handleSystemNTP(op string, sys *System) []string {
-	var cmds []string
+// handleSystemBanner maps config/motd-banner onto /system/note, the text RouterOS prints once a user
+// has logged in. An empty banner clears the note and stops it being shown. RouterOS has nothing it
+// shows before authentication, so login-banner is rejected rather than folded into the note.
+func handleSystemBanner(op string, sys *System, state *DeviceState) []string {
 	switch op {
+	case "get":
+		return []string{"/system/note/print"}
 	case "set":
-		if sys.NTP != nil {
-			if sys.NTP.Enabled != nil {
-				if *sys.NTP.Enabled {
-					cmds = append(cmds, "/system/ntp/client/set", "enabled=yes")
-				} else {
-					cmds = append(cmds, "/system/ntp/client/set", "enabled=no")
-				}
-			}
-			if sys.NTP.Servers != nil {
-				for _, s := range sys.NTP.Servers.Server {
-					if s.Address != nil && *s.Address != "" {
-						cmds = append(cmds, "/system/ntp/client/servers/add", "address="+*s.Address)
-					}
-				}
-			}
+		var cmds []string
+		if sys.Config.LoginBanner != nil {
+			cmds = append(cmds, "UNSUPPORTED: system/config login-banner, RouterOS has no pre-login banner")
 		}
-	case "get":
-		cmds = append(cmds, "/system/ntp/client/print")
-	}
-	return cmds
-}
-
-func handleSystemDNS(op string, sys *System) []string {
-	if op == "set" && sys.DNS != nil && sys.DNS.Servers != nil && len(sys.DNS.Servers.Server) > 0 {
-		servers := ""
-		for i, s := range sys.DNS.Servers.Server {
-			if i > 0 {
-				servers += ","
-			}
-			servers += s
+		if b := sys.Config.MotdBanner; b != nil {
+			note := strings.TrimRight(strings.ReplaceAll(*b, "\r\n", "\n"), "\n")
+			cmds = append(cmds, "/system/note/set note="+quote(note)+" show-at-login="+yesNo(note != ""))
 		}
-		return []string{"/ip/dns/set servers=" + servers}
+		return cmds
 	}
 	return nil
 }
@@ -0,0 +1,43 @@
+package openconfig
+
+// VLANs represents the openconfig-vlan top-level vlans container
+type VLANs struct {
+	VLAN []VLAN `xml:"vlan"`
+	// VLANFiltering is a vendor augmentation that switches vlan-filtering on the bridge
+	VLANFiltering *bool `xml:"vlan-filtering"`
+}
+
+type VLAN struct {
+	VlanID *uint16     `xml:"vlan-id"`
+	Config *VLANConfig `xml:"config"`
+}
+
+type VLANConfig struct {
+	VlanID *uint16 `xml:"vlan-id"`
+	Name   *string `xml:"name"`
+	Status *string `xml:"status"` // ACTIVE or SUSPENDED
+}
+
+// SwitchedVLAN is the openconfig-vlan augmentation of interfaces/interface/ethernet
+type SwitchedVLAN struct {
+	Operation *string             `xml:"operation,attr"`
+	Config    *SwitchedVLANConfig `xml:"config"`
+}
+
+type SwitchedVLANConfig struct {
+	InterfaceMode *string  `xml:"interface-mode"` // ACCESS or TRUNK
+	AccessVLAN    *uint16  `xml:"access-vlan"`
+	NativeVLAN    *uint16  `xml:"native-vlan"`
+	TrunkVLANs    []string `xml:"trunk-vlans"` // vlan-id or range, e.g. "100..200"
+}
+
+// id returns the VLAN key, falling back to config/vlan-id
+func (v *VLAN) id() (uint16, bool) {
+	if v.VlanID != nil {
+		return *v.VlanID, true
+	}
+	if v.Config != nil && v.Config.VlanID != nil {
+		return *v.Config.VlanID, true
+	}
+	return 0, false
+}
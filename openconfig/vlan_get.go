@@ -0,0 +1,13 @@
+package openconfig
+
+// VLANGetToMikrotikCmds parses the filter XML for vlans and interface switched-vlan
+// subtrees and dispatches to the VLAN handler for get operations.
+func VLANGetToMikrotikCmds(filterXML string) []string {
+	type vlanFilter struct {
+		VLANs      *VLANs      `xml:"vlans"`
+		Interfaces *Interfaces `xml:"interfaces"`
+	}
+	var f vlanFilter
+	_ = unmarshalFilter(filterXML, &f)
+	return VLANToMikrotikCmds("get", f.VLANs, f.Interfaces, nil)
+}
@@ -0,0 +1,298 @@
+package openconfig
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultBridgeName is the bridge RouterOS creates in its default configuration.
+// OpenConfig VLANs are not scoped to a bridge, so every VLAN is placed on it.
+const defaultBridgeName = "bridge"
+
+// bridgeVLANMembers collects the ports carrying a VLAN in the bridge VLAN table
+type bridgeVLANMembers struct {
+	tagged   []string
+	untagged []string
+}
+
+// VLANToMikrotikCmds maps openconfig-vlan vlans/vlan and interface switched-vlan config
+// onto /interface/bridge/port pvid and /interface/bridge/vlan tagged/untagged lists.
+// Bridge ports are found by interface in device state. With device state, bridge VLAN entries
+// are reconciled by vlan-ids: unchanged entries are kept, and changed ones are updated in place
+// with the payload's ports merged into the current members. A port leaves its VLANs only when its
+// switched-vlan carries a delete operation. vlan-filtering is only switched when the vlans
+// vendor leaf asks for it.
+func VLANToMikrotikCmds(op string, vlans *VLANs, ifs *Interfaces, state *DeviceState) []string {
+	var cmds []string
+	switch op {
+	case "get":
+		// Bridge VLAN membership is not mapped back to OpenConfig, so a get is not answered
+		if vlans != nil {
+			cmds = append(cmds, "UNSUPPORTED: vlans are not read back from /interface/bridge/vlan")
+		}
+		if hasSwitchedVLAN(ifs) {
+			cmds = append(cmds, "UNSUPPORTED: switched-vlan is not read back from /interface/bridge/port")
+		}
+	case "set":
+		cmds = vlanSetCmds(vlans, ifs, state)
+	}
+	return cmds
+}
+
+func vlanSetCmds(vlans *VLANs, ifs *Interfaces, state *DeviceState) []string {
+	var cmds []string
+	members := map[uint16]*bridgeVLANMembers{}
+	member := func(id uint16) *bridgeVLANMembers {
+		if members[id] == nil {
+			members[id] = &bridgeVLANMembers{}
+		}
+		return members[id]
+	}
+	removed := map[string]bool{}
+
+	if ifs != nil {
+		for _, i := range ifs.Interface {
+			name := i.name()
+			if name == "" || i.Ethernet == nil || i.Ethernet.SwitchedVLAN == nil {
+				continue
+			}
+			sv := i.Ethernet.SwitchedVLAN
+			deleting := isDeleteOperation(sv.Operation)
+			if !deleting && sv.Config == nil {
+				continue
+			}
+			port, ok := bridgePortSet(state, name)
+			if !ok {
+				cmds = append(cmds, port)
+				continue
+			}
+			if deleting {
+				// The port goes back to the bridge defaults and leaves every VLAN it was a member of
+				removed[name] = true
+				cmds = append(cmds, port+" pvid=1 frame-types=admit-all")
+				continue
+			}
+			cfg := sv.Config
+			mode := ""
+			if cfg.InterfaceMode != nil {
+				mode = identity(*cfg.InterfaceMode)
+			}
+			switch mode {
+			case "ACCESS":
+				if cfg.AccessVLAN == nil {
+					cmds = append(cmds, fmt.Sprintf("UNSUPPORTED: interfaces/interface %s switched-vlan ACCESS mode without access-vlan", name))
+					continue
+				}
+				cmds = append(cmds, fmt.Sprintf("%s pvid=%d frame-types=admit-only-untagged-and-priority-tagged", port, *cfg.AccessVLAN))
+				m := member(*cfg.AccessVLAN)
+				m.untagged = append(m.untagged, name)
+			case "TRUNK":
+				if cfg.NativeVLAN != nil {
+					// Untagged frames on a trunk land in the native VLAN
+					cmds = append(cmds, fmt.Sprintf("%s pvid=%d frame-types=admit-all", port, *cfg.NativeVLAN))
+					m := member(*cfg.NativeVLAN)
+					m.untagged = append(m.untagged, name)
+				} else {
+					cmds = append(cmds, port+" frame-types=admit-only-vlan-tagged")
+				}
+				for _, id := range expandVLANRanges(cfg.TrunkVLANs) {
+					if cfg.NativeVLAN != nil && id == *cfg.NativeVLAN {
+						continue
+					}
+					m := member(id)
+					m.tagged = append(m.tagged, name)
+				}
+			}
+		}
+	}
+
+	configs := map[uint16]*VLANConfig{}
+	if vlans != nil {
+		for _, v := range vlans.VLAN {
+			id, ok := v.id()
+			if !ok {
+				continue
+			}
+			member(id)
+			configs[id] = v.Config
+		}
+	}
+	// VLANs a removed port belongs to are rewritten without it
+	for _, row := range state.table("/interface/bridge/vlan") {
+		id, err := strconv.Atoi(row["vlan-ids"])
+		if err != nil || row["bridge"] != defaultBridgeName {
+			continue
+		}
+		for _, p := range append(splitList(row["tagged"]), splitList(row["untagged"])...) {
+			if removed[p] {
+				member(uint16(id))
+			}
+		}
+	}
+
+	ids := make([]int, 0, len(members))
+	for id := range members {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		m := members[uint16(id)]
+		cfg := configs[uint16(id)]
+		row := bridgeVLANRow(state, uint16(id))
+		if row == nil {
+			cmd := fmt.Sprintf("/interface/bridge/vlan/add bridge=%s vlan-ids=%d", defaultBridgeName, id)
+			if len(m.tagged) > 0 {
+				cmd += " tagged=" + strings.Join(m.tagged, ",")
+			}
+			if len(m.untagged) > 0 {
+				cmd += " untagged=" + strings.Join(m.untagged, ",")
+			}
+			cmds = append(cmds, cmd+bridgeVLANConfigArgs(cfg))
+			continue
+		}
+		merged := &bridgeVLANMembers{
+			tagged:   mergePorts(splitList(row["tagged"]), m.tagged, removed, m.untagged),
+			untagged: mergePorts(splitList(row["untagged"]), m.untagged, removed, m.tagged),
+		}
+		if bridgeVLANMatches(row, merged, cfg) {
+			continue
+		}
+		cmds = append(cmds, fmt.Sprintf("/interface/bridge/vlan/set .id=%s tagged=%s untagged=%s%s",
+			row[".id"], quote(strings.Join(merged.tagged, ",")), quote(strings.Join(merged.untagged, ",")), bridgeVLANConfigArgs(cfg)))
+	}
+
+	// Filtering goes last so the port and VLAN tables are in place before it takes effect
+	if vlans != nil && vlans.VLANFiltering != nil {
+		cmds = append(cmds, "/interface/bridge/set numbers="+defaultBridgeName+" vlan-filtering="+yesNo(*vlans.VLANFiltering))
+	}
+	return cmds
+}
+
+// bridgePortSet returns the set command prefix for an interface's port on the default bridge, or an
+// UNSUPPORTED line when the port cannot be found in device state
+func bridgePortSet(state *DeviceState, name string) (string, bool) {
+	if state == nil {
+		return fmt.Sprintf("UNSUPPORTED: interfaces/interface %s switched-vlan needs device state to find its bridge port", name), false
+	}
+	for _, row := range state.table("/interface/bridge/port") {
+		if row["interface"] == name && row["bridge"] == defaultBridgeName {
+			return "/interface/bridge/port/set .id=" + row[".id"], true
+		}
+	}
+	return fmt.Sprintf("UNSUPPORTED: interfaces/interface %s is not a port of bridge %s", name, defaultBridgeName), false
+}
+
+// mergePorts adds ports to a VLAN's current member list, leaving out removed ports and ports that
+// take the other role (tagged or untagged) in this edit
+func mergePorts(current, added []string, removed map[string]bool, other []string) []string {
+	drop := map[string]bool{}
+	for _, p := range other {
+		drop[p] = true
+	}
+	var out []string
+	seen := map[string]bool{}
+	for _, p := range append(append([]string{}, current...), added...) {
+		if p == "" || seen[p] || removed[p] || drop[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+// splitList splits a RouterOS comma-separated list value, returning nil for an empty one
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// bridgeVLANConfigArgs renders the vlan config kept on the bridge VLAN entry: the name as comment
+// and SUSPENDED as disabled
+func bridgeVLANConfigArgs(cfg *VLANConfig) string {
+	if cfg == nil {
+		return ""
+	}
+	args := ""
+	if cfg.Name != nil && *cfg.Name != "" {
+		args += " comment=" + quote(*cfg.Name)
+	}
+	if cfg.Status != nil {
+		args += " disabled=" + yesNo(identity(*cfg.Status) == "SUSPENDED")
+	}
+	return args
+}
+
+// bridgeVLANRow returns the entry of the default bridge's VLAN table for a single vlan-id
+func bridgeVLANRow(state *DeviceState, id uint16) map[string]string {
+	for _, row := range state.table("/interface/bridge/vlan") {
+		if row["bridge"] == defaultBridgeName && row["vlan-ids"] == strconv.Itoa(int(id)) {
+			return row
+		}
+	}
+	return nil
+}
+
+// bridgeVLANMatches reports whether a bridge VLAN entry already has the desired members and config
+func bridgeVLANMatches(row map[string]string, m *bridgeVLANMembers, cfg *VLANConfig) bool {
+	if row["tagged"] != strings.Join(m.tagged, ",") || row["untagged"] != strings.Join(m.untagged, ",") {
+		return false
+	}
+	if cfg == nil {
+		return true
+	}
+	if cfg.Name != nil && *cfg.Name != "" && row["comment"] != *cfg.Name {
+		return false
+	}
+	if cfg.Status != nil && (row["disabled"] == "true") != (identity(*cfg.Status) == "SUSPENDED") {
+		return false
+	}
+	return true
+}
+
+// expandVLANRanges expands trunk-vlans entries such as "10" or "100..200" into
+// a sorted, de-duplicated list of VLAN IDs. Invalid entries are skipped.
+func expandVLANRanges(entries []string) []uint16 {
+	seen := map[int]bool{}
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		lo, hi := e, e
+		if i := strings.Index(e, ".."); i >= 0 {
+			lo, hi = e[:i], e[i+2:]
+		}
+		start, err1 := strconv.Atoi(lo)
+		end, err2 := strconv.Atoi(hi)
+		if err1 != nil || err2 != nil || start < 1 || end > 4094 || start > end {
+			continue
+		}
+		for id := start; id <= end; id++ {
+			seen[id] = true
+		}
+	}
+	ids := make([]int, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	out := make([]uint16, len(ids))
+	for i, id := range ids {
+		out[i] = uint16(id)
+	}
+	return out
+}
+
+func hasSwitchedVLAN(ifs *Interfaces) bool {
+	if ifs == nil {
+		return false
+	}
+	for _, i := range ifs.Interface {
+		if i.Ethernet != nil && i.Ethernet.SwitchedVLAN != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVLAN_MikroTikCmd_Set_AccessAndTrunk(t *testing.T) {
+	access, trunk := "ether2", "ether3"
+	accessMode, trunkMode := "ACCESS", "oc-vlan-types:TRUNK"
+	var id10, id20 uint16 = 10, 20
+	name := "users"
+	filtering := true
+	vlans := &VLANs{VLAN: []VLAN{{VlanID: &id10, Config: &VLANConfig{Name: &name}}}, VLANFiltering: &filtering}
+	ifs := &Interfaces{Interface: []Interface{
+		{Name: &access, Ethernet: &InterfaceEthernet{SwitchedVLAN: &SwitchedVLAN{Config: &SwitchedVLANConfig{
+			InterfaceMode: &accessMode, AccessVLAN: &id10,
+		}}}},
+		{Name: &trunk, Ethernet: &InterfaceEthernet{SwitchedVLAN: &SwitchedVLAN{Config: &SwitchedVLANConfig{
+			InterfaceMode: &trunkMode, NativeVLAN: &id20, TrunkVLANs: []string{"10", "20..22"},
+		}}}},
+	}}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/interface/bridge/port": {
+			{".id": "*A", "bridge": "bridge", "interface": "ether2"},
+			{".id": "*B", "bridge": "bridge", "interface": "ether3"},
+		},
+	}}
+	cmds := VLANToMikrotikCmds("set", vlans, ifs, state)
+	expected := []string{
+		"/interface/bridge/port/set .id=*A pvid=10 frame-types=admit-only-untagged-and-priority-tagged",
+		"/interface/bridge/port/set .id=*B pvid=20 frame-types=admit-all",
+		`/interface/bridge/vlan/add bridge=bridge vlan-ids=10 tagged=ether3 untagged=ether2 comment="users"`,
+		"/interface/bridge/vlan/add bridge=bridge vlan-ids=20 untagged=ether3",
+		"/interface/bridge/vlan/add bridge=bridge vlan-ids=21 tagged=ether3",
+		"/interface/bridge/vlan/add bridge=bridge vlan-ids=22 tagged=ether3",
+		"/interface/bridge/set numbers=bridge vlan-filtering=yes",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestVLAN_MikroTikCmd_Set_ReconcileByVLANID(t *testing.T) {
+	trunk := "ether3"
+	trunkMode := "TRUNK"
+	var id10, id20 uint16 = 10, 20
+	name := "users"
+	vlans := &VLANs{VLAN: []VLAN{{VlanID: &id10, Config: &VLANConfig{Name: &name}}, {VlanID: &id20}}}
+	ifs := &Interfaces{Interface: []Interface{
+		{Name: &trunk, Ethernet: &InterfaceEthernet{SwitchedVLAN: &SwitchedVLAN{Config: &SwitchedVLANConfig{
+			InterfaceMode: &trunkMode, TrunkVLANs: []string{"10", "20"},
+		}}}},
+	}}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/interface/bridge/vlan": {
+			{".id": "*1", "bridge": "bridge", "vlan-ids": "10", "tagged": "ether3", "untagged": "", "comment": "users"},
+			{".id": "*2", "bridge": "bridge", "vlan-ids": "20", "tagged": "ether2", "untagged": "ether3,ether4"},
+			{".id": "*3", "bridge": "other", "vlan-ids": "20", "tagged": "ether3"},
+		},
+		"/interface/bridge/port": {{".id": "*B", "bridge": "bridge", "interface": "ether3"}},
+	}}
+	// ether3 joins VLAN 20 tagged, leaving its untagged membership; ether2 and ether4 are kept
+	cmds := VLANToMikrotikCmds("set", vlans, ifs, state)
+	expected := []string{
+		"/interface/bridge/port/set .id=*B frame-types=admit-only-vlan-tagged",
+		`/interface/bridge/vlan/set .id=*2 tagged="ether2,ether3" untagged="ether4"`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestVLAN_MikroTikCmd_Set_DeletePort(t *testing.T) {
+	trunk, remove := "ether3", "delete"
+	ifs := &Interfaces{Interface: []Interface{
+		{Name: &trunk, Ethernet: &InterfaceEthernet{SwitchedVLAN: &SwitchedVLAN{Operation: &remove}}},
+	}}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/interface/bridge/vlan": {
+			{".id": "*1", "bridge": "bridge", "vlan-ids": "10", "tagged": "ether2,ether3", "untagged": ""},
+			{".id": "*2", "bridge": "bridge", "vlan-ids": "20", "tagged": "ether2", "untagged": "ether4"},
+		},
+		"/interface/bridge/port": {{".id": "*B", "bridge": "bridge", "interface": "ether3"}},
+	}}
+	cmds := VLANToMikrotikCmds("set", nil, ifs, state)
+	expected := []string{
+		"/interface/bridge/port/set .id=*B pvid=1 frame-types=admit-all",
+		`/interface/bridge/vlan/set .id=*1 tagged="ether2" untagged=""`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestVLAN_MikroTikCmd_Set_PortNotFound(t *testing.T) {
+	access, accessMode := "ether2", "ACCESS"
+	var id10 uint16 = 10
+	ifs := &Interfaces{Interface: []Interface{
+		{Name: &access, Ethernet: &InterfaceEthernet{SwitchedVLAN: &SwitchedVLAN{Config: &SwitchedVLANConfig{
+			InterfaceMode: &accessMode, AccessVLAN: &id10,
+		}}}},
+	}}
+	cmds := VLANToMikrotikCmds("set", nil, ifs, nil)
+	expected := []string{"UNSUPPORTED: interfaces/interface ether2 switched-vlan needs device state to find its bridge port"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+	cmds = VLANToMikrotikCmds("set", nil, ifs, &DeviceState{})
+	expected = []string{"UNSUPPORTED: interfaces/interface ether2 is not a port of bridge bridge"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestVLAN_MikroTikCmd_Set_AccessWithoutVLAN(t *testing.T) {
+	access := "ether2"
+	accessMode := "ACCESS"
+	ifs := &Interfaces{Interface: []Interface{
+		{Name: &access, Ethernet: &InterfaceEthernet{SwitchedVLAN: &SwitchedVLAN{Config: &SwitchedVLANConfig{
+			InterfaceMode: &accessMode,
+		}}}},
+	}}
+	state := &DeviceState{Tables: map[string][]map[string]string{
+		"/interface/bridge/port": {{".id": "*A", "bridge": "bridge", "interface": "ether2"}},
+	}}
+	cmds := VLANToMikrotikCmds("set", nil, ifs, state)
+	expected := []string{"UNSUPPORTED: interfaces/interface ether2 switched-vlan ACCESS mode without access-vlan"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestVLAN_MikroTikCmd_Set_Empty(t *testing.T) {
+	cmds := VLANToMikrotikCmds("set", nil, &Interfaces{}, nil)
+	if len(cmds) > 0 {
+		t.Errorf("expected no commands, got %v", cmds)
+	}
+}
+
+func TestExpandVLANRanges(t *testing.T) {
+	ids := expandVLANRanges([]string{"5..7", "6", "bogus", "4000..5000", "100"})
+	expected := []uint16{5, 6, 7, 100}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestVLANGetToMikrotikCmds(t *testing.T) {
+	xml := `<vlans/><interfaces><interface><ethernet><switched-vlan/></ethernet></interface></interfaces>`
+	cmds := VLANGetToMikrotikCmds(xml)
+	expected := []string{
+		"UNSUPPORTED: vlans are not read back from /interface/bridge/vlan",
+		"UNSUPPORTED: switched-vlan is not read back from /interface/bridge/port",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
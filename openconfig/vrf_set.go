@@ -0,0 +1,92 @@
+package openconfig
+
+import "strings"
+
+// handleNetworkInstanceVRF maps an L3VRF network-instance onto RouterOS. On RouterOS 7 an
+// instance with interfaces becomes an /ip/vrf entry, which brings its own routing table;
+// without interfaces only a /routing/table is created (VRF-lite). Its route-distinguisher
+// goes on the VRF's /routing/bgp/vpn entry. RouterOS 6 has no /ip/vrf: an instance without
+// interfaces is VRF-lite, a routing-mark its routes carry that needs no entry of its own, and
+// an instance with interfaces becomes an /ip/route/vrf entry keyed by routing-mark, which also
+// carries the route-distinguisher. Existing entries are updated in place. An interfaces list
+// that no longer names any interface removes the VRF entry, leaving the instance VRF-lite.
+func handleNetworkInstanceVRF(op string, ni *NetworkInstance, state *DeviceState) []string {
+	v6 := state.majorVersion() < 7
+	switch op {
+	case "get":
+		if v6 {
+			return []string{"/ip/route/vrf/print"}
+		}
+		return []string{"/ip/vrf/print", "/routing/table/print"}
+	case "set":
+		name := ni.name()
+		if name == "" {
+			return nil
+		}
+		interfaces := strings.Join(ni.interfaceNames(), ",")
+		var rd string
+		if ni.Config != nil && ni.Config.RouteDistinguisher != nil {
+			rd = *ni.Config.RouteDistinguisher
+		}
+
+		if v6 {
+			row := state.find("/ip/route/vrf", "routing-mark", name)
+			switch {
+			case interfaces != "":
+				attrs := []attr{{"interfaces", interfaces}}
+				if rd != "" {
+					attrs = append(attrs, attr{"route-distinguisher", rd})
+				}
+				return vrfUpsertCmds(state, "/ip/route/vrf", "routing-mark", name, attrs)
+			case row != nil && ni.Interfaces != nil:
+				// The routing-mark outlives the entry, so the instance carries on as VRF-lite
+				cmds := []string{"/ip/route/vrf/remove .id=" + row[".id"]}
+				if rd != "" {
+					cmds = append(cmds, "UNSUPPORTED: network-instance "+name+" route-distinguisher requires interfaces on RouterOS 6")
+				}
+				return cmds
+			case row != nil && rd != "":
+				return vrfUpsertCmds(state, "/ip/route/vrf", "routing-mark", name, []attr{{"route-distinguisher", rd}})
+			case rd != "":
+				return []string{"UNSUPPORTED: network-instance " + name + " route-distinguisher requires interfaces on RouterOS 6"}
+			}
+			return nil
+		}
+
+		var cmds []string
+		row := state.find("/ip/vrf", "name", name)
+		vpn := state.find("/routing/bgp/vpn", "vrf", name)
+		hasVRF := interfaces != "" || (row != nil && ni.Interfaces == nil)
+		switch {
+		case interfaces != "":
+			cmds = vrfUpsertCmds(state, "/ip/vrf", "name", name, []attr{{"interfaces", interfaces}})
+		case row != nil && ni.Interfaces != nil:
+			// An /ip/vrf entry needs interfaces; its routing table goes with it, so the instance
+			// gets a plain one for its routes. A BGP VPN entry would keep the VRF in use.
+			if vpn != nil {
+				cmds = append(cmds, "/routing/bgp/vpn/remove .id="+vpn[".id"])
+			}
+			cmds = append(cmds, "/ip/vrf/remove .id="+row[".id"], "/routing/table/add name="+name+" fib")
+		case row == nil && state.find("/routing/table", "name", name) == nil:
+			cmds = append(cmds, "/routing/table/add name="+name+" fib")
+		}
+		if rd != "" {
+			if !hasVRF {
+				cmds = append(cmds, "UNSUPPORTED: network-instance "+name+" route-distinguisher requires interfaces on RouterOS 7")
+			} else {
+				cmds = append(cmds, vrfUpsertCmds(state, "/routing/bgp/vpn", "vrf", name, []attr{{"route-distinguisher", rd}})...)
+			}
+		}
+		return cmds
+	}
+	return nil
+}
+
+// vrfUpsertCmds adds or updates the VRF entry keyed by keyAttr, leaving an entry that already
+// matches alone
+func vrfUpsertCmds(state *DeviceState, menu, keyAttr, name string, attrs []attr) []string {
+	if row := state.find(menu, keyAttr, name); row != nil && rowMatches(row, attrs, attrKeys(attrs)) {
+		return nil
+	}
+	return []string{upsertCmd(state, menu, keyAttr, name, renderAttrs(attrs))}
+}
@@ -0,0 +1,201 @@
+package openconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNetworkInstanceVRF_MikroTikCmd_Set_RouterOS7(t *testing.T) {
+	custA, mgmt, typ := "cust-a", "mgmt", "oc-ni-types:L3VRF"
+	eth5, eth6 := "ether5", "ether6"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{
+		{
+			Name:       &custA,
+			Config:     &NetworkInstanceConfig{Name: &custA, Type: &typ},
+			Interfaces: &NetworkInstanceInterfaces{Interface: []NetworkInstanceInterface{{ID: &eth5}, {ID: &eth6}}},
+		},
+		{Name: &mgmt, Config: &NetworkInstanceConfig{Name: &mgmt, Type: &typ}},
+	}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, &DeviceState{Version: "7.12.1 (stable)"})
+	expected := []string{
+		"/ip/vrf/add name=cust-a interfaces=ether5,ether6",
+		"/routing/table/add name=mgmt fib",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestNetworkInstanceVRF_MikroTikCmd_Set_RouterOS6(t *testing.T) {
+	name, typ, rd, eth5 := "cust-a", "oc-ni-types:L3VRF", "65000:1", "ether5"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name:       &name,
+		Config:     &NetworkInstanceConfig{Name: &name, Type: &typ, RouteDistinguisher: &rd},
+		Interfaces: &NetworkInstanceInterfaces{Interface: []NetworkInstanceInterface{{ID: &eth5}}},
+	}}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, &DeviceState{Version: "6.49.10 (long-term)"})
+	expected := []string{"/ip/route/vrf/add routing-mark=cust-a interfaces=ether5 route-distinguisher=65000:1"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestNetworkInstanceVRF_StaticRoutesScoped(t *testing.T) {
+	name, typ, eth5 := "cust-a", "oc-ni-types:L3VRF", "ether5"
+	prefix, addr, idx, id := "10.1.0.0/16", "192.0.2.1", "0", "STATIC"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name:       &name,
+		Config:     &NetworkInstanceConfig{Name: &name, Type: &typ},
+		Interfaces: &NetworkInstanceInterfaces{Interface: []NetworkInstanceInterface{{ID: &eth5}}},
+		Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{
+			Identifier: &id,
+			StaticRoutes: &StaticRoutes{Static: []StaticRoute{{
+				Prefix:   &prefix,
+				NextHops: &StaticNextHops{NextHop: []StaticNextHop{{Index: &idx, Config: &StaticNextHopConfig{NextHop: &addr}}}},
+			}}},
+		}}},
+	}}}
+
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, nil)
+	expected := []string{
+		"/ip/vrf/add name=cust-a interfaces=ether5",
+		`/ip/route/add dst-address=10.1.0.0/16 gateway=192.0.2.1 routing-table=cust-a comment="0"`,
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+
+	// A route already present in the VRF's table is left alone; one in main is not ours to touch
+	state := &DeviceState{Version: "6.49.10", Tables: map[string][]map[string]string{
+		"/ip/route": {
+			{".id": "*1", "dst-address": "10.1.0.0/16", "gateway": "192.0.2.1", "distance": "1", "routing-mark": "cust-a", "comment": "0"},
+			{".id": "*2", "dst-address": "10.1.0.0/16", "gateway": "192.0.2.9"},
+		},
+	}}
+	cmds = NetworkInstancesToMikrotikCmds("set", nis, state)
+	expected = []string{"/ip/route/vrf/add routing-mark=cust-a interfaces=ether5"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestNetworkInstanceVRF_MikroTikCmd_Set_Reconcile(t *testing.T) {
+	custA, custB, mgmt, typ := "cust-a", "cust-b", "mgmt", "oc-ni-types:L3VRF"
+	eth5, eth6, eth7 := "ether5", "ether6", "ether7"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{
+		{
+			Name:       &custA,
+			Config:     &NetworkInstanceConfig{Name: &custA, Type: &typ},
+			Interfaces: &NetworkInstanceInterfaces{Interface: []NetworkInstanceInterface{{ID: &eth5}, {ID: &eth6}}},
+		},
+		{
+			Name:       &custB,
+			Config:     &NetworkInstanceConfig{Name: &custB, Type: &typ},
+			Interfaces: &NetworkInstanceInterfaces{Interface: []NetworkInstanceInterface{{ID: &eth7}}},
+		},
+		{Name: &mgmt, Config: &NetworkInstanceConfig{Name: &mgmt, Type: &typ}},
+	}}
+	state := &DeviceState{Version: "7.12.1 (stable)", Tables: map[string][]map[string]string{
+		"/ip/vrf": {
+			{".id": "*1", "name": "cust-a", "interfaces": "ether5,ether6"},
+			{".id": "*2", "name": "cust-b", "interfaces": "ether8"},
+		},
+		"/routing/table": {{".id": "*3", "name": "mgmt", "fib": ""}},
+	}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, state)
+	expected := []string{"/ip/vrf/set .id=*2 interfaces=ether7"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestNetworkInstanceVRF_MikroTikCmd_Set_RouterOS6Lite(t *testing.T) {
+	mgmt, custB, typ, rd := "mgmt", "cust-b", "oc-ni-types:L3VRF", "65000:2"
+	prefix, addr, idx, id := "2001:db8:1::/48", "2001:db8::1", "0", "STATIC"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{
+		{
+			Name:   &mgmt,
+			Config: &NetworkInstanceConfig{Name: &mgmt, Type: &typ},
+			Protocols: &NetworkInstanceProtocols{Protocol: []Protocol{{
+				Identifier: &id,
+				StaticRoutes: &StaticRoutes{Static: []StaticRoute{{
+					Prefix:   &prefix,
+					NextHops: &StaticNextHops{NextHop: []StaticNextHop{{Index: &idx, Config: &StaticNextHopConfig{NextHop: &addr}}}},
+				}}},
+			}}},
+		},
+		{Name: &custB, Config: &NetworkInstanceConfig{Name: &custB, Type: &typ, RouteDistinguisher: &rd}},
+	}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, &DeviceState{Version: "6.49.10 (long-term)"})
+	expected := []string{
+		"UNSUPPORTED: network-instance mgmt static route 2001:db8:1::/48 is IPv6, which RouterOS 6 cannot scope to a routing-mark",
+		"UNSUPPORTED: network-instance cust-b route-distinguisher requires interfaces on RouterOS 6",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestNetworkInstanceVRF_MikroTikCmd_Set_RouteDistinguisherRouterOS7(t *testing.T) {
+	custA, custB, mgmt, typ, eth5 := "cust-a", "cust-b", "mgmt", "oc-ni-types:L3VRF", "ether5"
+	rdA, rdB, rdM := "65000:1", "65000:2", "65000:3"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{
+		{
+			Name:       &custA,
+			Config:     &NetworkInstanceConfig{Name: &custA, Type: &typ, RouteDistinguisher: &rdA},
+			Interfaces: &NetworkInstanceInterfaces{Interface: []NetworkInstanceInterface{{ID: &eth5}}},
+		},
+		// An existing VRF keeps its interfaces when the payload does not list them
+		{Name: &custB, Config: &NetworkInstanceConfig{Name: &custB, Type: &typ, RouteDistinguisher: &rdB}},
+		{Name: &mgmt, Config: &NetworkInstanceConfig{Name: &mgmt, Type: &typ, RouteDistinguisher: &rdM}},
+	}}
+	state := &DeviceState{Version: "7.12.1 (stable)", Tables: map[string][]map[string]string{
+		"/ip/vrf": {
+			{".id": "*1", "name": "cust-a", "interfaces": "ether5"},
+			{".id": "*2", "name": "cust-b", "interfaces": "ether7"},
+		},
+		"/routing/bgp/vpn": {{".id": "*5", "vrf": "cust-b", "route-distinguisher": "65000:9"}},
+	}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, state)
+	expected := []string{
+		"/routing/bgp/vpn/add vrf=cust-a route-distinguisher=65000:1",
+		"/routing/bgp/vpn/set .id=*5 route-distinguisher=65000:2",
+		"/routing/table/add name=mgmt fib",
+		"UNSUPPORTED: network-instance mgmt route-distinguisher requires interfaces on RouterOS 7",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
+
+func TestNetworkInstanceVRF_MikroTikCmd_Set_InterfacesRemoved(t *testing.T) {
+	name, typ := "cust-a", "oc-ni-types:L3VRF"
+	nis := &NetworkInstances{NetworkInstance: []NetworkInstance{{
+		Name:       &name,
+		Config:     &NetworkInstanceConfig{Name: &name, Type: &typ},
+		Interfaces: &NetworkInstanceInterfaces{},
+	}}}
+
+	state := &DeviceState{Version: "7.12.1 (stable)", Tables: map[string][]map[string]string{
+		"/ip/vrf":          {{".id": "*1", "name": "cust-a", "interfaces": "ether5"}},
+		"/routing/bgp/vpn": {{".id": "*5", "vrf": "cust-a", "route-distinguisher": "65000:1"}},
+	}}
+	cmds := NetworkInstancesToMikrotikCmds("set", nis, state)
+	expected := []string{
+		"/routing/bgp/vpn/remove .id=*5",
+		"/ip/vrf/remove .id=*1",
+		"/routing/table/add name=cust-a fib",
+	}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+
+	state = &DeviceState{Version: "6.49.10 (long-term)", Tables: map[string][]map[string]string{
+		"/ip/route/vrf": {{".id": "*2", "routing-mark": "cust-a", "interfaces": "ether5"}},
+	}}
+	cmds = NetworkInstancesToMikrotikCmds("set", nis, state)
+	expected = []string{"/ip/route/vrf/remove .id=*2"}
+	if !reflect.DeepEqual(cmds, expected) {
+		t.Errorf("expected %v, got %v", expected, cmds)
+	}
+}
@@ -56,14 +56,44 @@ type Target struct {
 }
 
 type Config struct {
-	System *openconfig.System `xml:"system"`
+	System           *openconfig.System           `xml:"system"`
+	Interfaces       *openconfig.Interfaces       `xml:"interfaces"`
+	VLANs            *openconfig.VLANs            `xml:"vlans"`
+	RoutingPolicy    *openconfig.RoutingPolicy    `xml:"routing-policy"`
+	NetworkInstances *openconfig.NetworkInstances `xml:"network-instances"`
+	ACL              *openconfig.ACL              `xml:"acl"`
+	NAT              *openconfig.NAT              `xml:"nat"` // vendor augmentation
+	QoS              *openconfig.QoS              `xml:"qos"`
+	LLDP             *openconfig.LLDP             `xml:"lldp"`
+	DHCPServer       *openconfig.DHCPServer       `xml:"dhcp-server"` // vendor augmentation
 	// Extend for more OpenConfig modules
 }
 
+// Data is the <data> of a get reply, built from the device's print replies by the module mappers
+type Data struct {
+	XMLName          xml.Name                     `xml:"data"`
+	System           *openconfig.System           `xml:"system"`
+	Interfaces       *openconfig.Interfaces       `xml:"interfaces"`
+	LACP             *openconfig.LACP             `xml:"lacp"`
+	NetworkInstances *openconfig.NetworkInstances `xml:"network-instances"`
+	ACL              *openconfig.ACL              `xml:"acl"`
+	NAT              *openconfig.NAT              `xml:"nat"` // vendor augmentation
+	QoS              *openconfig.QoS              `xml:"qos"`
+	LLDP             *openconfig.LLDP             `xml:"lldp"`
+	Components       *openconfig.Components       `xml:"components"`
+	DHCPServer       *openconfig.DHCPServer       `xml:"dhcp-server"` // vendor augmentation
+}
+
 // --- Translation Logic ---
 
 // TranslateNetconfToMikrotik takes NETCONF XML and returns MikroTik API commands
 func TranslateNetconfToMikrotik(xmlInput string) ([]string, error) {
+	return TranslateNetconfToMikrotikWithState(xmlInput, nil)
+}
+
+// TranslateNetconfToMikrotikWithState is TranslateNetconfToMikrotik with the device's current
+// configuration (see LoadDeviceState), so keyed lists are reconciled rather than re-added
+func TranslateNetconfToMikrotikWithState(xmlInput string, state *openconfig.DeviceState) ([]string, error) {
 	// Step 1: Validate against OpenConfig schema
 	if err := ValidateOpenConfigSchema(xmlInput); err != nil {
 		return nil, fmt.Errorf("schema validation failed: %w", err)
@@ -85,7 +115,7 @@ func TranslateNetconfToMikrotik(xmlInput string) ([]string, error) {
 
 	// Handle <edit-config>
 	if rpc.EditConfig != nil {
-		editCmds, err := handleEditConfig(rpc.EditConfig)
+		editCmds, err := handleEditConfig(rpc.EditConfig, state)
 		if err != nil {
 			return nil, err
 		}
@@ -106,14 +136,67 @@ func TranslateNetconfToMikrotik(xmlInput string) ([]string, error) {
 // --- Handlers for NETCONF operations ---
 
 func handleGet(get *Get) []string {
-	// Delegate to openconfig system get handler
-	return openconfig.SystemGetToMikrotikCmds(get.Filter.Value)
+	// Delegate to the openconfig get handler of each module in the filter
+	cmds := openconfig.SystemGetToMikrotikCmds(get.Filter.Value)
+	cmds = append(cmds, openconfig.InterfacesGetToMikrotikCmds(get.Filter.Value)...)
+	cmds = append(cmds, openconfig.VLANGetToMikrotikCmds(get.Filter.Value)...)
+	cmds = append(cmds, openconfig.LACPGetToMikrotikCmds(get.Filter.Value)...)
+	cmds = append(cmds, openconfig.RoutingPolicyGetToMikrotikCmds(get.Filter.Value)...)
+	cmds = append(cmds, openconfig.NetworkInstancesGetToMikrotikCmds(get.Filter.Value)...)
+	cmds = append(cmds, openconfig.ACLGetToMikrotikCmds(get.Filter.Value)...)
+	cmds = append(cmds, openconfig.NATGetToMikrotikCmds(get.Filter.Value)...)
+	cmds = append(cmds, openconfig.QoSGetToMikrotikCmds(get.Filter.Value)...)
+	cmds = append(cmds, openconfig.LLDPGetToMikrotikCmds(get.Filter.Value)...)
+	cmds = append(cmds, openconfig.PlatformGetToMikrotikCmds(get.Filter.Value)...)
+	cmds = append(cmds, openconfig.DHCPServerGetToMikrotikCmds(get.Filter.Value)...)
+	return cmds
+}
+
+// BuildGetReply maps the replies to the print commands TranslateNetconfToMikrotik returned for a
+// NETCONF <get> back into the OpenConfig tree, rendered as the <data> of the reply
+func BuildGetReply(xmlInput string, replies openconfig.Replies) ([]byte, error) {
+	var rpc NetconfRPC
+	if err := xml.Unmarshal([]byte(xmlInput), &rpc); err != nil {
+		return nil, fmt.Errorf("failed to parse NETCONF XML: %w", err)
+	}
+	if rpc.Get == nil {
+		return nil, errors.New("no NETCONF get found")
+	}
+	return xml.Marshal(handleGetReply(rpc.Get, replies))
 }
 
-func handleEditConfig(edit *EditConfig) ([]string, error) {
+func handleGetReply(get *Get, replies openconfig.Replies) *Data {
+	// Each module rebuilds the part of the tree its filter selects
+	return &Data{
+		System:           openconfig.SystemGetFromMikrotik(get.Filter.Value, replies),
+		Interfaces:       openconfig.InterfacesGetFromMikrotik(get.Filter.Value, replies),
+		LACP:             openconfig.LACPGetFromMikrotik(get.Filter.Value, replies),
+		NetworkInstances: openconfig.NetworkInstancesGetFromMikrotik(get.Filter.Value, replies),
+		ACL:              openconfig.ACLGetFromMikrotik(get.Filter.Value, replies),
+		NAT:              openconfig.NATGetFromMikrotik(get.Filter.Value, replies),
+		QoS:              openconfig.QoSGetFromMikrotik(get.Filter.Value, replies),
+		LLDP:             openconfig.LLDPGetFromMikrotik(get.Filter.Value, replies),
+		Components:       openconfig.PlatformGetFromMikrotik(get.Filter.Value, replies),
+		DHCPServer:       openconfig.DHCPServerGetFromMikrotik(get.Filter.Value, replies),
+	}
+}
+
+func handleEditConfig(edit *EditConfig, state *openconfig.DeviceState) ([]string, error) {
 	var cmds []string
+	if err := openconfig.CheckManagementLockout(edit.Config.System, state); err != nil {
+		return nil, err
+	}
 	// Delegate to openconfig system set handler (registry-based)
-	cmds = append(cmds, openconfig.SystemToMikrotikCmdsRegistry("set", edit.Config.System)...)
+	cmds = append(cmds, openconfig.SystemToMikrotikCmdsRegistry("set", edit.Config.System, state)...)
+	cmds = append(cmds, openconfig.InterfacesToMikrotikCmdsRegistry("set", edit.Config.Interfaces, state)...)
+	cmds = append(cmds, openconfig.VLANToMikrotikCmds("set", edit.Config.VLANs, edit.Config.Interfaces, state)...)
+	cmds = append(cmds, openconfig.RoutingPolicyToMikrotikCmds("set", edit.Config.RoutingPolicy, state)...)
+	cmds = append(cmds, openconfig.NetworkInstancesToMikrotikCmds("set", edit.Config.NetworkInstances, state)...)
+	cmds = append(cmds, openconfig.ACLToMikrotikCmds("set", edit.Config.ACL, state)...)
+	cmds = append(cmds, openconfig.NATToMikrotikCmds("set", edit.Config.NAT, state)...)
+	cmds = append(cmds, openconfig.QoSToMikrotikCmds("set", edit.Config.QoS, state)...)
+	cmds = append(cmds, openconfig.LLDPToMikrotikCmds("set", edit.Config.LLDP, state)...)
+	cmds = append(cmds, openconfig.DHCPServerToMikrotikCmds("set", edit.Config.DHCPServer, state)...)
 	// Extend for more OpenConfig modules
 	if len(cmds) == 0 {
 		return nil, errors.New("no supported edit-config elements found")